@@ -42,6 +42,7 @@ package crossplane
 //go:generate sh -c "sh ./scripts/generate/generate.sh --url https://github.com/leev/ngx_http_geoip2_module.git --config-path ./scripts/generate/configs/geoip2_config.json > ./analyze_geoip2_directives.gen.go"
 import (
 	"fmt"
+	"math/bits"
 )
 
 // bit masks for different directive argument styles.
@@ -61,6 +62,10 @@ const (
 	ngxConf1More = 0x00001000 // >=1 args
 	ngxConf2More = 0x00002000 // >=2 args
 
+	// ngxConfUnique is not in the NGINX source, the generator sets it on the directives that
+	// NGINX rejects with "directive is duplicate" when they appear twice in a block.
+	ngxConfUnique = 0x00004000
+
 	// some helpful argument style aliases.
 	ngxConfTake12   = ngxConfTake1 | ngxConfTake2
 	ngxConfTake13   = ngxConfTake1 | ngxConfTake3
@@ -95,31 +100,104 @@ const ngxAnyConf = ngxMainConf | ngxEventConf | ngxMailMainConf | ngxMailSrvConf
 	ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPUpsConf |
 	ngxHTTPSifConf | ngxHTTPLifConf | ngxHTTPLmtConf
 
+// BlockContext describes a block context, so that the directives inside it can be
+// checked. Third-party modules can add their own with ParseOptions.BlockContexts.
+type BlockContext struct {
+	// Path is the block context, like ["http", "server"]. Its last element is the name
+	// of the block directive that opens it.
+	Path []string
+
+	// Mask is the ContextMask that directives use to be allowed in the context. It can
+	// be one of the predefined contexts, to check the block like that context, or a new
+	// one from CustomContext.
+	Mask ContextMask
+
+	// If true, the block directive opens Path anywhere inside Path[:len(Path)-1], however
+	// deeply it is nested. This is how a location block is always in the http>location
+	// context. Otherwise the name of a block directive is appended to the context it
+	// appears in.
+	Nested bool
+}
+
+// customContexts is the number of masks of CustomContext, which are the upper half of a
+// 64-bit uint.
+const customContexts = 32
+
+// CustomContext returns the n-th ContextMask left for the contexts of third-party modules,
+// with n from 0 to 31. These masks need a 64-bit uint, so CustomContext panics on
+// platforms where uint has 32 bits, like 386 and arm, and when n is out of range.
+func CustomContext(n int) ContextMask {
+	if bits.UintSize < 64 {
+		panic("crossplane: CustomContext needs a 64-bit uint")
+	}
+	if n < 0 || n >= customContexts {
+		panic(fmt.Sprintf("crossplane: CustomContext(%d) is out of range, n must be from 0 to %d", n, customContexts-1))
+	}
+	return ContextMask(uint(1) << (32 + n))
+}
+
+// enters reports whether a block directive named directive in ctx opens the context of c.
+func (c BlockContext) enters(directive string, ctx blockCtx) bool {
+	if !c.Nested || len(c.Path) == 0 || c.Path[len(c.Path)-1] != directive || len(ctx) < len(c.Path)-1 {
+		return false
+	}
+	return equals(ctx[:len(c.Path)-1], c.Path[:len(c.Path)-1])
+}
+
+// the block contexts of NGINX and its modules
+//
+//nolint:gochecknoglobals
+var defaultBlockContexts = []BlockContext{
+	{Path: []string{}, Mask: ContextMain},
+	{Path: []string{"events"}, Mask: ContextEvents},
+	{Path: []string{"mail"}, Mask: ContextMail},
+	{Path: []string{"mail", "server"}, Mask: ContextMailServer},
+	{Path: []string{"stream"}, Mask: ContextStream},
+	{Path: []string{"stream", "server"}, Mask: ContextStreamServer},
+	{Path: []string{"stream", "upstream"}, Mask: ContextStreamUpstream},
+	{Path: []string{"http"}, Mask: ContextHTTP},
+	{Path: []string{"http", "server"}, Mask: ContextHTTPServer},
+	// don't nest because ngxHTTPLocConf just means "location block in http"
+	{Path: []string{"http", "location"}, Mask: ContextHTTPLocation, Nested: true},
+	{Path: []string{"http", "upstream"}, Mask: ContextHTTPUpstream},
+	{Path: []string{"http", "server", "if"}, Mask: ContextHTTPServerIf},
+	{Path: []string{"http", "location", "if"}, Mask: ContextHTTPLocationIf},
+	{Path: []string{"http", "location", "limit_except"}, Mask: ContextHTTPLimitExcept},
+	{Path: []string{"mgmt"}, Mask: ContextMgmt},
+}
+
 // map for getting bitmasks from certain context tuples
 //
 //nolint:gochecknoglobals
-var contexts = map[string]uint{
-	blockCtx{}.key():                                   ngxMainConf,
-	blockCtx{"events"}.key():                           ngxEventConf,
-	blockCtx{"mail"}.key():                             ngxMailMainConf,
-	blockCtx{"mail", "server"}.key():                   ngxMailSrvConf,
-	blockCtx{"stream"}.key():                           ngxStreamMainConf,
-	blockCtx{"stream", "server"}.key():                 ngxStreamSrvConf,
-	blockCtx{"stream", "upstream"}.key():               ngxStreamUpsConf,
-	blockCtx{"http"}.key():                             ngxHTTPMainConf,
-	blockCtx{"http", "server"}.key():                   ngxHTTPSrvConf,
-	blockCtx{"http", "location"}.key():                 ngxHTTPLocConf,
-	blockCtx{"http", "upstream"}.key():                 ngxHTTPUpsConf,
-	blockCtx{"http", "server", "if"}.key():             ngxHTTPSifConf,
-	blockCtx{"http", "location", "if"}.key():           ngxHTTPLifConf,
-	blockCtx{"http", "location", "limit_except"}.key(): ngxHTTPLmtConf,
-	blockCtx{"mgmt"}.key():                             ngxMgmtMainConf,
+var contexts = func() map[string]uint {
+	m := make(map[string]uint, len(defaultBlockContexts))
+	for _, c := range defaultBlockContexts {
+		m[blockCtx(c.Path).key()] = uint(c.Mask)
+	}
+	return m
+}()
+
+// contextMask returns the bitmask of ctx, looking at the custom contexts first.
+func contextMask(ctx blockCtx, custom []BlockContext) (uint, bool) {
+	key := ctx.key()
+	for _, c := range custom {
+		if blockCtx(c.Path).key() == key {
+			return uint(c.Mask), true
+		}
+	}
+	mask, ok := contexts[key]
+	return mask, ok
 }
 
-func enterBlockCtx(stmt *Directive, ctx blockCtx) blockCtx {
-	// don't nest because ngxHTTPLocConf just means "location block in http"
-	if len(ctx) > 0 && ctx[0] == "http" && stmt.Directive == "location" {
-		return blockCtx{"http", "location"}
+// enterBlockCtx returns the context of the block of stmt, which appears in ctx. The
+// nesting rules of the custom contexts come before the default ones.
+func enterBlockCtx(stmt *Directive, ctx blockCtx, custom []BlockContext) blockCtx {
+	for _, rules := range [][]BlockContext{custom, defaultBlockContexts} {
+		for _, c := range rules {
+			if c.enters(stmt.Directive, ctx) {
+				return append(blockCtx{}, c.Path...)
+			}
+		}
 	}
 	// no other block contexts can be nested like location so just append it
 	return append(ctx, stmt.Directive)
@@ -130,7 +208,7 @@ func analyze(fname string, stmt *Directive, term string, ctx blockCtx, options *
 	var masks []uint
 	knownDirective := false
 
-	currCtx, knownContext := contexts[ctx.key()]
+	currCtx, knownContext := contextMask(ctx, options.BlockContexts)
 	directiveName := stmt.Directive
 
 	// Find all bitmasks from the sources invoker provides.
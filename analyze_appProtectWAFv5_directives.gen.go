@@ -11,6 +11,7 @@
 //   - how many arguments the directive can take
 //   - whether or not it is a block directive
 //   - whether this is a flag (takes one argument that's either "on" or "off")
+//   - whether it may appear only once in a block
 //   - which contexts it's allowed to be in
 
 package crossplane
@@ -86,3 +87,8 @@ func MatchAppProtectWAFv5(directive string) ([]uint, bool) {
     m, ok := appProtectWAFv5Directives[directive]
     return m, ok
 }
+
+// AppProtectWAFv5Registry returns a Registry with the directives of MatchAppProtectWAFv5.
+func AppProtectWAFv5Registry() *Registry {
+    return registryFromMasks("app-protect-waf", appProtectWAFv5Directives)
+}
@@ -0,0 +1,242 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"fmt"
+	"strings"
+)
+
+// listenSocketOptions are listen parameters that configure the listening socket and may
+// therefore only be given once for every address and port.
+//
+//nolint:gochecknoglobals
+var listenSocketOptions = []string{
+	"backlog=", "rcvbuf=", "sndbuf=", "accept_filter=", "setfib=", "fastopen=",
+	"so_keepalive=", "ipv6only=", "bind", "deferred", "reuseport",
+}
+
+// dupSite is a directive together with the file it was parsed from.
+type dupSite struct {
+	file string
+	stmt *Directive
+	ctx  blockCtx
+}
+
+func (s dupSite) errorf(format string, a ...interface{}) *ParseError {
+	file := s.file
+	line := s.stmt.Line
+	return &ParseError{
+		What:      fmt.Sprintf(format, a...),
+		File:      &file,
+		Line:      &line,
+		Statement: s.stmt.String(),
+		BlockCtx:  s.ctx.getLastBlock(),
+	}
+}
+
+type duplicateChecker struct {
+	registry      *Registry
+	blockContexts []BlockContext
+
+	errs []*ParseError
+	// reported keeps a file included in several blocks from reporting the same error twice
+	reported map[string]struct{}
+}
+
+// dupBlock identifies the block of a directive, with the contents of included files in
+// place of the include directives: the block directive, or nil for the main context of
+// the Config the walk started at.
+type dupBlock struct {
+	parent *Directive
+	root   int
+}
+
+// checkDuplicates returns an error for every directive that appears more than once in a
+// block although the registry has it as Unique, and for every pair of http servers that
+// NGINX would reject or ignore because of their listen and server_name directives.
+func checkDuplicates(payload *Payload, registry *Registry, blockContexts []BlockContext) []*ParseError {
+	c := &duplicateChecker{
+		registry:      registry,
+		blockContexts: blockContexts,
+		reported:      map[string]struct{}{},
+	}
+
+	blocks := map[dupBlock][]dupSite{}
+	var order []dupBlock
+	// the servers of each http block
+	servers := map[dupBlock][]dupBlock{}
+	var httpBlocks []dupBlock
+
+	_ = newPayloadWalker(payload, blockContexts, false).walk(func(s *walkSite) error {
+		key := dupBlock{s.parent(), s.root}
+		if _, ok := blocks[key]; !ok {
+			order = append(order, key)
+		}
+		blocks[key] = append(blocks[key], dupSite{file: s.file, stmt: s.stmt, ctx: s.ctx})
+
+		if s.stmt.Directive == "server" && s.stmt.IsBlock() && s.ctx.key() == "http" {
+			if _, ok := servers[key]; !ok {
+				httpBlocks = append(httpBlocks, key)
+			}
+			servers[key] = append(servers[key], dupBlock{s.stmt, s.root})
+		}
+		return nil
+	})
+
+	for _, key := range order {
+		c.checkBlock(blocks[key])
+	}
+	for _, key := range httpBlocks {
+		var sites [][]dupSite
+		for _, server := range servers[key] {
+			sites = append(sites, blocks[server])
+		}
+		c.checkServers(sites)
+	}
+	return c.errs
+}
+
+func (c *duplicateChecker) report(err *ParseError) {
+	key := err.Error()
+	if _, ok := c.reported[key]; ok {
+		return
+	}
+	c.reported[key] = struct{}{}
+	c.errs = append(c.errs, err)
+}
+
+// checkBlock reports the directives that may not repeat but do in the sites of a block.
+func (c *duplicateChecker) checkBlock(sites []dupSite) {
+	seen := map[string]struct{}{}
+	for _, site := range sites {
+		name := site.stmt.Directive
+		if c.unique(site) {
+			if _, dup := seen[name]; dup {
+				c.report(site.errorf(`"%s" directive is duplicate`, name))
+			}
+			seen[name] = struct{}{}
+		}
+	}
+}
+
+// unique reports whether the directive of a site may not repeat, by its specs for the
+// context of the site, or by all of them if the context isn't known.
+func (c *duplicateChecker) unique(site dupSite) bool {
+	specs, _ := c.registry.Lookup(site.stmt.Directive)
+	mask, known := contextMask(site.ctx, c.blockContexts)
+	for _, spec := range specs {
+		if spec.Unique && (!known || uint(spec.Contexts)&mask != 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkServers looks for listen and server_name conflicts between the servers of an http block.
+func (c *duplicateChecker) checkServers(servers [][]dupSite) {
+	defaults := map[string]struct{}{}
+	socketOpts := map[string]struct{}{}
+	names := map[string]struct{}{}
+
+	for _, server := range servers {
+		var addrs []string
+		for _, site := range server {
+			if site.stmt.Directive != "listen" || len(site.stmt.Args) == 0 {
+				continue
+			}
+			addr := listenAddress(site.stmt.Args[0])
+			addrs = append(addrs, addr)
+			params := site.stmt.Args[1:]
+
+			if contains(params, "default_server") || contains(params, "default") {
+				if _, dup := defaults[addr]; dup {
+					c.report(site.errorf("a duplicate default server for %s", addr))
+				}
+				defaults[addr] = struct{}{}
+			}
+			if hasSocketOption(params) {
+				if _, dup := socketOpts[addr]; dup {
+					c.report(site.errorf("duplicate listen options for %s", addr))
+				}
+				socketOpts[addr] = struct{}{}
+			}
+		}
+		if len(addrs) == 0 {
+			addrs = []string{"*:80"}
+		}
+
+		for _, site := range server {
+			if site.stmt.Directive != "server_name" {
+				continue
+			}
+			for _, name := range site.stmt.Args {
+				if name == "" {
+					continue
+				}
+				for _, addr := range addrs {
+					key := strings.ToLower(name) + " " + addr
+					if _, dup := names[key]; dup {
+						c.report(site.errorf(`conflicting server name "%s" on %s`, name, addr))
+					}
+					names[key] = struct{}{}
+				}
+			}
+		}
+	}
+}
+
+// listenAddress normalizes the address of a listen directive to the "host:port" form
+// NGINX uses in its own messages.
+func listenAddress(arg string) string {
+	if strings.HasPrefix(arg, "unix:") {
+		return arg
+	}
+	host, port := "*", "80"
+	switch {
+	case strings.HasPrefix(arg, "["):
+		end := strings.Index(arg, "]")
+		if end < 0 {
+			return arg
+		}
+		host = arg[:end+1]
+		if p := strings.TrimPrefix(arg[end+1:], ":"); p != "" {
+			port = p
+		}
+	case strings.Contains(arg, ":"):
+		host, port, _ = strings.Cut(arg, ":")
+	case isNumeric(arg):
+		port = arg
+	default:
+		host = arg
+	}
+	if host == "0.0.0.0" {
+		host = "*"
+	}
+	return host + ":" + port
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func hasSocketOption(params []string) bool {
+	for _, p := range params {
+		for _, opt := range listenSocketOptions {
+			if p == opt || (strings.HasSuffix(opt, "=") && strings.HasPrefix(p, opt)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -11,6 +11,7 @@
 //   - how many arguments the directive can take
 //   - whether or not it is a block directive
 //   - whether this is a flag (takes one argument that's either "on" or "off")
+//   - whether it may appear only once in a block
 //   - which contexts it's allowed to be in
 
 package crossplane
@@ -33,3 +34,8 @@ func MatchGeoip2Latest(directive string) ([]uint, bool) {
     m, ok := geoip2Directives[directive]
     return m, ok
 }
+
+// Geoip2LatestRegistry returns a Registry with the directives of MatchGeoip2Latest.
+func Geoip2LatestRegistry() *Registry {
+    return registryFromMasks("geoip2", geoip2Directives)
+}
@@ -11,6 +11,7 @@
 //   - how many arguments the directive can take
 //   - whether or not it is a block directive
 //   - whether this is a flag (takes one argument that's either "on" or "off")
+//   - whether it may appear only once in a block
 //   - which contexts it's allowed to be in
 
 package crossplane
@@ -35,3 +36,8 @@ func MatchHeadersMoreLatest(directive string) ([]uint, bool) {
     m, ok := headersMoreDirectives[directive]
     return m, ok
 }
+
+// HeadersMoreLatestRegistry returns a Registry with the directives of MatchHeadersMoreLatest.
+func HeadersMoreLatestRegistry() *Registry {
+    return registryFromMasks("headers-more", headersMoreDirectives)
+}
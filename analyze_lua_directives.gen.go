@@ -11,6 +11,7 @@
 //   - how many arguments the directive can take
 //   - whether or not it is a block directive
 //   - whether this is a flag (takes one argument that's either "on" or "off")
+//   - whether it may appear only once in a block
 //   - which contexts it's allowed to be in
 
 package crossplane
@@ -260,3 +261,8 @@ func MatchLuaLatest(directive string) ([]uint, bool) {
     m, ok := luaDirectives[directive]
     return m, ok
 }
+
+// LuaLatestRegistry returns a Registry with the directives of MatchLuaLatest.
+func LuaLatestRegistry() *Registry {
+    return registryFromMasks("lua", luaDirectives)
+}
@@ -11,6 +11,7 @@
 //   - how many arguments the directive can take
 //   - whether or not it is a block directive
 //   - whether this is a flag (takes one argument that's either "on" or "off")
+//   - whether it may appear only once in a block
 //   - which contexts it's allowed to be in
 
 package crossplane
@@ -101,3 +102,8 @@ func MatchNjsLatest(directive string) ([]uint, bool) {
     m, ok := njsDirectives[directive]
     return m, ok
 }
+
+// NjsLatestRegistry returns a Registry with the directives of MatchNjsLatest.
+func NjsLatestRegistry() *Registry {
+    return registryFromMasks("njs", njsDirectives)
+}
@@ -10,6 +10,7 @@
 //   - how many arguments the directive can take
 //   - whether or not it is a block directive
 //   - whether this is a flag (takes one argument that's either "on" or "off")
+//   - whether it may appear only once in a block
 //   - which contexts it's allowed to be in
 
 package crossplane
@@ -17,10 +18,10 @@ package crossplane
 //nolint:gochecknoglobals
 var nginxPlusR31Directives = map[string][]uint{
 	"absolute_redirect": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"accept_mutex": {
-		ngxEventConf | ngxConfFlag,
+		ngxEventConf | ngxConfFlag | ngxConfUnique,
 	},
 	"accept_mutex_delay": {
 		ngxEventConf | ngxConfTake1,
@@ -48,10 +49,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"aio_write": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"alias": {
-		ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"allow": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLmtConf | ngxConfTake1,
@@ -82,7 +83,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxMailMainConf | ngxMailSrvConf | ngxConfTake2,
 	},
 	"auth_http_pass_client_cert": {
-		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
+		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"auth_http_timeout": {
 		ngxMailMainConf | ngxMailSrvConf | ngxConfTake1,
@@ -121,16 +122,16 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
 	},
 	"autoindex": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"autoindex_exact_size": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"autoindex_format": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"autoindex_localtime": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"break": {
 		ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfNoArgs,
@@ -145,31 +146,31 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"chunked_transfer_encoding": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"client_body_buffer_size": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"client_body_in_file_only": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"client_body_in_single_buffer": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"client_body_temp_path": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1234,
 	},
 	"client_body_timeout": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"client_header_buffer_size": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"client_header_timeout": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"client_max_body_size": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"connect_timeout": {
 		ngxConfTake1 | ngxMgmtMainConf,
@@ -178,10 +179,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
 	},
 	"create_full_put_path": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"daemon": {
-		ngxMainConf | ngxDirectConf | ngxConfFlag,
+		ngxMainConf | ngxDirectConf | ngxConfFlag | ngxConfUnique,
 	},
 	"dav_access": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake123,
@@ -196,7 +197,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxMainConf | ngxDirectConf | ngxConfTake1,
 	},
 	"default_type": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"deny": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLmtConf | ngxConfTake1,
@@ -227,13 +228,13 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConf2More,
 	},
 	"etag": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"events": {
-		ngxMainConf | ngxConfBlock | ngxConfNoArgs,
+		ngxMainConf | ngxConfBlock | ngxConfNoArgs | ngxConfUnique,
 	},
 	"expires": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake12,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake12 | ngxConfUnique,
 	},
 	"f4f": {
 		ngxHTTPLocConf | ngxConfNoArgs,
@@ -248,7 +249,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"fastcgi_buffering": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_buffers": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
@@ -260,16 +261,16 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"fastcgi_cache_background_update": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_cache_bypass": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"fastcgi_cache_key": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"fastcgi_cache_lock": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_cache_lock_age": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -293,7 +294,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"fastcgi_cache_revalidate": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_cache_use_stale": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -308,13 +309,13 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"fastcgi_force_ranges": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_hide_header": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"fastcgi_ignore_client_abort": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_ignore_headers": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -323,10 +324,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"fastcgi_intercept_errors": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_keep_conn": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_limit_rate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -350,22 +351,22 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake23,
 	},
 	"fastcgi_pass": {
-		ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+		ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"fastcgi_pass_header": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"fastcgi_pass_request_body": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_pass_request_headers": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_read_timeout": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"fastcgi_request_buffering": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_send_lowat": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -374,7 +375,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"fastcgi_socket_keepalive": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"fastcgi_split_path_info": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -414,7 +415,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxConfTake1,
 	},
 	"geoip_proxy_recursive": {
-		ngxHTTPMainConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxConfFlag | ngxConfUnique,
 	},
 	"google_perftools_profiles": {
 		ngxMainConf | ngxDirectConf | ngxConfTake1,
@@ -435,7 +436,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"grpc_intercept_errors": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"grpc_next_upstream": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -447,7 +448,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"grpc_pass": {
-		ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+		ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"grpc_pass_header": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -462,7 +463,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
 	},
 	"grpc_socket_keepalive": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"grpc_ssl_certificate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -489,34 +490,34 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"grpc_ssl_server_name": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"grpc_ssl_session_reuse": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"grpc_ssl_trusted_certificate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"grpc_ssl_verify": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"grpc_ssl_verify_depth": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"gunzip": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"gunzip_buffers": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
 	},
 	"gzip": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
 	},
 	"gzip_buffers": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
 	},
 	"gzip_comp_level": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"gzip_disable": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -537,7 +538,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"gzip_vary": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"hash": {
 		ngxHTTPUpsConf | ngxConfTake12,
@@ -557,7 +558,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
 	},
 	"hls_forward_args": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"hls_fragment": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -569,10 +570,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"http": {
-		ngxMainConf | ngxConfBlock | ngxConfNoArgs,
+		ngxMainConf | ngxConfBlock | ngxConfNoArgs | ngxConfUnique,
 	},
 	"http2": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"http2_body_preread_size": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -602,7 +603,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"http2_push_preload": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"http2_recv_buffer_size": {
 		ngxHTTPMainConf | ngxConfTake1,
@@ -611,10 +612,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
 	},
 	"http3": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"http3_hq": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"http3_max_concurrent_streams": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -629,7 +630,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"ignore_invalid_headers": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"image_filter": {
 		ngxHTTPLocConf | ngxConfTake123,
@@ -638,7 +639,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"image_filter_interlace": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"image_filter_jpeg_quality": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -647,7 +648,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"image_filter_transparency": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"image_filter_webp_quality": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -668,7 +669,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"internal": {
-		ngxHTTPLocConf | ngxConfNoArgs,
+		ngxHTTPLocConf | ngxConfNoArgs | ngxConfUnique,
 	},
 	"internal_redirect": {
 		ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -683,16 +684,16 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
 	},
 	"keepalive_requests": {
-		ngxHTTPUpsConf | ngxConfTake1,
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPUpsConf | ngxConfTake1 | ngxConfUnique,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"keepalive_time": {
 		ngxHTTPUpsConf | ngxConfTake1,
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"keepalive_timeout": {
-		ngxHTTPUpsConf | ngxConfTake1,
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
+		ngxHTTPUpsConf | ngxConfTake1 | ngxConfUnique,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12 | ngxConfUnique,
 	},
 	"keyval": {
 		ngxHTTPMainConf | ngxConfTake3 | ngxConfTake4,
@@ -703,7 +704,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxConf1More,
 	},
 	"large_client_header_buffers": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake2,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake2 | ngxConfUnique,
 	},
 	"least_conn": {
 		ngxHTTPUpsConf | ngxConfNoArgs,
@@ -718,8 +719,8 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake2,
 	},
 	"limit_conn_dry_run": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"limit_conn_log_level": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -736,7 +737,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPLocConf | ngxConfBlock | ngxConf1More,
 	},
 	"limit_rate": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"limit_rate_after": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
@@ -745,7 +746,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake123,
 	},
 	"limit_req_dry_run": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"limit_req_log_level": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -784,13 +785,13 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxConf2More,
 	},
 	"log_not_found": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"log_subrequest": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"mail": {
-		ngxMainConf | ngxConfBlock | ngxConfNoArgs,
+		ngxMainConf | ngxConfBlock | ngxConfNoArgs | ngxConfUnique,
 	},
 	"map": {
 		ngxHTTPMainConf | ngxConfBlock | ngxConfTake2,
@@ -805,7 +806,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxConfTake1,
 	},
 	"master_process": {
-		ngxMainConf | ngxDirectConf | ngxConfFlag,
+		ngxMainConf | ngxDirectConf | ngxConfFlag | ngxConfUnique,
 	},
 	"match": {
 		ngxHTTPMainConf | ngxConfBlock | ngxConfTake12,
@@ -839,7 +840,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"memcached_pass": {
-		ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+		ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"memcached_read_timeout": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -848,10 +849,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"memcached_socket_keepalive": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"merge_slashes": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"mgmt": {
 		ngxMainConf | ngxDirectConf | ngxConfBlock | ngxConfNoArgs,
@@ -863,7 +864,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"mirror_request_body": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"modern_browser": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
@@ -887,31 +888,31 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"mp4_start_key_frame": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"mqtt": {
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"mqtt_buffers": {
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake2,
 	},
 	"mqtt_preread": {
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"mqtt_rewrite_buffer_size": {
-		ngxStreamSrvConf | ngxConfFlag,
+		ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"mqtt_set_connect": {
 		ngxStreamSrvConf | ngxConfTake2,
 	},
 	"msie_padding": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"msie_refresh": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"multi_accept": {
-		ngxEventConf | ngxConfFlag,
+		ngxEventConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ntlm": {
 		ngxHTTPUpsConf | ngxConfNoArgs,
@@ -920,7 +921,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
 	},
 	"open_file_cache_errors": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"open_file_cache_min_uses": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -936,10 +937,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
 	},
 	"override_charset": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
 	},
 	"pcre_jit": {
-		ngxMainConf | ngxDirectConf | ngxConfFlag,
+		ngxMainConf | ngxDirectConf | ngxConfFlag | ngxConfUnique,
 	},
 	"perl": {
 		ngxHTTPLocConf | ngxHTTPLmtConf | ngxConfTake1,
@@ -954,7 +955,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxConfTake2,
 	},
 	"pid": {
-		ngxMainConf | ngxDirectConf | ngxConfTake1,
+		ngxMainConf | ngxDirectConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"pop3_auth": {
 		ngxMailMainConf | ngxMailSrvConf | ngxConf1More,
@@ -963,7 +964,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxMailMainConf | ngxMailSrvConf | ngxConf1More,
 	},
 	"port_in_redirect": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"postpone_output": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -985,35 +986,35 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxMailMainConf | ngxMailSrvConf | ngxConfTake1,
 	},
 	"proxy_buffer_size": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"proxy_buffering": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_buffers": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2 | ngxConfUnique,
 	},
 	"proxy_busy_buffers_size": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"proxy_cache": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"proxy_cache_background_update": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_cache_bypass": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"proxy_cache_convert_head": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_cache_key": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"proxy_cache_lock": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_cache_lock_age": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1037,7 +1038,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"proxy_cache_revalidate": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_cache_use_stale": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -1046,8 +1047,8 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"proxy_connect_timeout": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"proxy_cookie_domain": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
@@ -1062,10 +1063,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
 	},
 	"proxy_force_ranges": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_half_close": {
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_headers_hash_bucket_size": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1077,16 +1078,16 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"proxy_http_version": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"proxy_ignore_client_abort": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_ignore_headers": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"proxy_intercept_errors": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_limit_rate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1099,7 +1100,7 @@ var nginxPlusR31Directives = map[string][]uint{
 	},
 	"proxy_next_upstream": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_next_upstream_timeout": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1113,36 +1114,36 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"proxy_pass": {
-		ngxHTTPLocConf | ngxHTTPLifConf | ngxHTTPLmtConf | ngxConfTake1,
-		ngxStreamSrvConf | ngxConfTake1,
+		ngxHTTPLocConf | ngxHTTPLifConf | ngxHTTPLmtConf | ngxConfTake1 | ngxConfUnique,
+		ngxStreamSrvConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"proxy_pass_error_message": {
-		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
+		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_pass_header": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"proxy_pass_request_body": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_pass_request_headers": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_protocol": {
-		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_protocol_timeout": {
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
 	},
 	"proxy_read_timeout": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"proxy_redirect": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
 	},
 	"proxy_request_buffering": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_requests": {
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
@@ -1154,10 +1155,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"proxy_send_timeout": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"proxy_session_drop": {
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_set_body": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1166,14 +1167,14 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
 	},
 	"proxy_smtp_auth": {
-		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
+		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_socket_keepalive": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_ssl": {
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_ssl_certificate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1208,20 +1209,20 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConf1More,
 	},
 	"proxy_ssl_server_name": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_ssl_session_reuse": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_ssl_trusted_certificate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
 	},
 	"proxy_ssl_verify": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"proxy_ssl_verify_depth": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1253,23 +1254,23 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
 	},
 	"quic_bpf": {
-		ngxMainConf | ngxDirectConf | ngxConfFlag,
+		ngxMainConf | ngxDirectConf | ngxConfFlag | ngxConfUnique,
 	},
 	"quic_gso": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"quic_host_key": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
 	},
 	"quic_retry": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"random": {
 		ngxHTTPUpsConf | ngxConfNoArgs | ngxConfTake12,
 		ngxStreamUpsConf | ngxConfNoArgs | ngxConfTake12,
 	},
 	"random_index": {
-		ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"read_ahead": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1281,10 +1282,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"real_ip_recursive": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"recursive_error_pages": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"referer_hash_bucket_size": {
 		ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1296,7 +1297,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
 	},
 	"reset_timedout_connection": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"resolver": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -1322,10 +1323,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake23,
 	},
 	"rewrite_log": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
 	},
 	"root": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"satisfy": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1337,7 +1338,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"scgi_buffering": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"scgi_buffers": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
@@ -1349,16 +1350,16 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"scgi_cache_background_update": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"scgi_cache_bypass": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"scgi_cache_key": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"scgi_cache_lock": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"scgi_cache_lock_age": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1382,7 +1383,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"scgi_cache_revalidate": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"scgi_cache_use_stale": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -1394,19 +1395,19 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"scgi_force_ranges": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"scgi_hide_header": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"scgi_ignore_client_abort": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"scgi_ignore_headers": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"scgi_intercept_errors": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"scgi_limit_rate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1430,28 +1431,28 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake23,
 	},
 	"scgi_pass": {
-		ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+		ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"scgi_pass_header": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"scgi_pass_request_body": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"scgi_pass_request_headers": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"scgi_read_timeout": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"scgi_request_buffering": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"scgi_send_timeout": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"scgi_socket_keepalive": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"scgi_store": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1478,11 +1479,11 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"send_timeout": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
-		ngxConfTake1 | ngxMgmtMainConf,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
+		ngxConfTake1 | ngxMgmtMainConf | ngxConfUnique,
 	},
 	"sendfile": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
 	},
 	"sendfile_max_chunk": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1499,16 +1500,16 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxMailMainConf | ngxMailSrvConf | ngxConfTake1,
 	},
 	"server_name_in_redirect": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"server_names_hash_bucket_size": {
-		ngxHTTPMainConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"server_names_hash_max_size": {
-		ngxHTTPMainConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"server_tokens": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"session_log": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1551,16 +1552,16 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxConfBlock | ngxConfTake2,
 	},
 	"ssi": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ssi_last_modified": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ssi_min_file_chunk": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"ssi_silent_errors": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ssi_types": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -1569,7 +1570,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"ssl": {
-		ngxConfFlag | ngxMgmtMainConf,
+		ngxConfFlag | ngxMgmtMainConf | ngxConfUnique,
 	},
 	"ssl_alpn": {
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConf1More,
@@ -1590,10 +1591,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
 	},
 	"ssl_ciphers": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
-		ngxMailMainConf | ngxMailSrvConf | ngxConfTake1,
-		ngxConfTake1 | ngxMgmtMainConf,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1 | ngxConfUnique,
+		ngxMailMainConf | ngxMailSrvConf | ngxConfTake1 | ngxConfUnique,
+		ngxConfTake1 | ngxMgmtMainConf | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"ssl_client_certificate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -1617,7 +1618,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
 	},
 	"ssl_early_data": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ssl_ecdh_curve": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -1634,7 +1635,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxConfTake1 | ngxMgmtMainConf,
 	},
 	"ssl_ocsp": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ssl_ocsp_cache": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -1649,12 +1650,12 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
 	},
 	"ssl_prefer_server_ciphers": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
-		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
+		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ssl_preread": {
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ssl_protocols": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConf1More,
@@ -1663,10 +1664,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConf1More,
 	},
 	"ssl_reject_handshake": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ssl_server_name": {
-		ngxConfFlag | ngxMgmtMainConf,
+		ngxConfFlag | ngxMgmtMainConf | ngxConfUnique,
 	},
 	"ssl_session_cache": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake12,
@@ -1679,17 +1680,17 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
 	},
 	"ssl_session_tickets": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
-		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
+		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ssl_session_timeout": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
-		ngxMailMainConf | ngxMailSrvConf | ngxConfTake1,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1 | ngxConfUnique,
+		ngxMailMainConf | ngxMailSrvConf | ngxConfTake1 | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"ssl_stapling": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ssl_stapling_file": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -1698,7 +1699,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
 	},
 	"ssl_stapling_verify": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"ssl_trusted_certificate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -1707,7 +1708,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
 	},
 	"ssl_verify": {
-		ngxConfFlag | ngxMgmtMainConf,
+		ngxConfFlag | ngxMgmtMainConf | ngxConfUnique,
 	},
 	"ssl_verify_client": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -1739,7 +1740,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPUpsConf | ngxConf2More,
 	},
 	"stream": {
-		ngxMainConf | ngxConfBlock | ngxConfNoArgs,
+		ngxMainConf | ngxConfBlock | ngxConfNoArgs | ngxConfUnique,
 	},
 	"stub_status": {
 		ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfNoArgs | ngxConfTake1,
@@ -1748,10 +1749,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
 	},
 	"sub_filter_last_modified": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"sub_filter_once": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"sub_filter_types": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -1760,11 +1761,11 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"tcp_nodelay": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"tcp_nopush": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"thread_pool": {
 		ngxMainConf | ngxDirectConf | ngxConfTake23,
@@ -1776,7 +1777,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxMainConf | ngxDirectConf | ngxConfTake1,
 	},
 	"try_files": {
-		ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf2More,
+		ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf2More | ngxConfUnique,
 	},
 	"types": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfBlock | ngxConfNoArgs,
@@ -1785,13 +1786,13 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"types_hash_max_size": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"underscores_in_headers": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uninitialized_variable_warn": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
 	},
 	"upstream": {
 		ngxHTTPMainConf | ngxConfBlock | ngxConfTake1,
@@ -1804,7 +1805,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxEventConf | ngxConfTake1,
 	},
 	"user": {
-		ngxMainConf | ngxDirectConf | ngxConfTake12,
+		ngxMainConf | ngxDirectConf | ngxConfTake12 | ngxConfUnique,
 	},
 	"userid": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1843,7 +1844,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"uwsgi_buffering": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_buffers": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
@@ -1861,10 +1862,10 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"uwsgi_cache_key": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"uwsgi_cache_lock": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_cache_lock_age": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1888,7 +1889,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"uwsgi_cache_revalidate": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_cache_use_stale": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -1900,19 +1901,19 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"uwsgi_force_ranges": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_hide_header": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"uwsgi_ignore_client_abort": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_ignore_headers": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"uwsgi_intercept_errors": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_limit_rate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1942,28 +1943,28 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake23,
 	},
 	"uwsgi_pass": {
-		ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+		ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"uwsgi_pass_header": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"uwsgi_pass_request_body": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_pass_request_headers": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_read_timeout": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"uwsgi_request_buffering": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_send_timeout": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"uwsgi_socket_keepalive": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_ssl_certificate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1990,16 +1991,16 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
 	},
 	"uwsgi_ssl_server_name": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_ssl_session_reuse": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_ssl_trusted_certificate": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"uwsgi_ssl_verify": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"uwsgi_ssl_verify_depth": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -2031,7 +2032,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxEventConf | ngxConfTake1,
 	},
 	"worker_connections": {
-		ngxEventConf | ngxConfTake1,
+		ngxEventConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"worker_cpu_affinity": {
 		ngxMainConf | ngxDirectConf | ngxConf1More,
@@ -2040,13 +2041,13 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxMainConf | ngxDirectConf | ngxConfTake1,
 	},
 	"worker_processes": {
-		ngxMainConf | ngxDirectConf | ngxConfTake1,
+		ngxMainConf | ngxDirectConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"worker_rlimit_core": {
 		ngxMainConf | ngxDirectConf | ngxConfTake1,
 	},
 	"worker_rlimit_nofile": {
-		ngxMainConf | ngxDirectConf | ngxConfTake1,
+		ngxMainConf | ngxDirectConf | ngxConfTake1 | ngxConfUnique,
 	},
 	"worker_shutdown_timeout": {
 		ngxMainConf | ngxDirectConf | ngxConfTake1,
@@ -2055,13 +2056,13 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxMainConf | ngxDirectConf | ngxConfTake1,
 	},
 	"xclient": {
-		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
+		ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"xml_entities": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
 	},
 	"xslt_last_modified": {
-		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
 	},
 	"xslt_param": {
 		ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
@@ -2101,7 +2102,7 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamSrvConf | ngxConfTake12,
 	},
 	"zone_sync_ssl": {
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"zone_sync_ssl_certificate": {
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
@@ -2128,13 +2129,13 @@ var nginxPlusR31Directives = map[string][]uint{
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConf1More,
 	},
 	"zone_sync_ssl_server_name": {
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"zone_sync_ssl_trusted_certificate": {
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
 	},
 	"zone_sync_ssl_verify": {
-		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+		ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
 	},
 	"zone_sync_ssl_verify_depth": {
 		ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
@@ -2148,3 +2149,8 @@ func MatchNginxPlusR31(directive string) ([]uint, bool) {
 	masks, matched := nginxPlusR31Directives[directive]
 	return masks, matched
 }
+
+// NginxPlusR31Registry returns a Registry with the directives of MatchNginxPlusR31.
+func NginxPlusR31Registry() *Registry {
+	return registryFromMasks("nginx-plus", nginxPlusR31Directives)
+}
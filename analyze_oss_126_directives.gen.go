@@ -11,16 +11,17 @@
 //   - how many arguments the directive can take
 //   - whether or not it is a block directive
 //   - whether this is a flag (takes one argument that's either "on" or "off")
+//   - whether it may appear only once in a block
 //   - which contexts it's allowed to be in
 
 package crossplane
 
 var oss126Directives = map[string][]uint{
     "absolute_redirect": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "accept_mutex": {
-        ngxEventConf | ngxConfFlag,
+        ngxEventConf | ngxConfFlag | ngxConfUnique,
     },
     "accept_mutex_delay": {
         ngxEventConf | ngxConfTake1,
@@ -48,10 +49,10 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "aio_write": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "alias": {
-        ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "allow": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLmtConf | ngxConfTake1,
@@ -79,7 +80,7 @@ var oss126Directives = map[string][]uint{
         ngxMailMainConf | ngxMailSrvConf | ngxConfTake2,
     },
     "auth_http_pass_client_cert": {
-        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
+        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "auth_http_timeout": {
         ngxMailMainConf | ngxMailSrvConf | ngxConfTake1,
@@ -91,16 +92,16 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
     },
     "autoindex": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "autoindex_exact_size": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "autoindex_format": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "autoindex_localtime": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "break": {
         ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfNoArgs,
@@ -115,40 +116,40 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "chunked_transfer_encoding": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "client_body_buffer_size": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "client_body_in_file_only": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "client_body_in_single_buffer": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "client_body_temp_path": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1234,
     },
     "client_body_timeout": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "client_header_buffer_size": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1 | ngxConfUnique,
     },
     "client_header_timeout": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1 | ngxConfUnique,
     },
     "client_max_body_size": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "connection_pool_size": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
     },
     "create_full_put_path": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "daemon": {
-        ngxMainConf | ngxDirectConf | ngxConfFlag,
+        ngxMainConf | ngxDirectConf | ngxConfFlag | ngxConfUnique,
     },
     "dav_access": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake123,
@@ -163,7 +164,7 @@ var oss126Directives = map[string][]uint{
         ngxMainConf | ngxDirectConf | ngxConfTake1,
     },
     "default_type": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "deny": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLmtConf | ngxConfTake1,
@@ -194,13 +195,13 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConf2More,
     },
     "etag": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "events": {
-        ngxMainConf | ngxConfBlock | ngxConfNoArgs,
+        ngxMainConf | ngxConfBlock | ngxConfNoArgs | ngxConfUnique,
     },
     "expires": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake12,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake12 | ngxConfUnique,
     },
     "fastcgi_bind": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
@@ -209,7 +210,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "fastcgi_buffering": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_buffers": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
@@ -221,16 +222,16 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "fastcgi_cache_background_update": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_cache_bypass": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "fastcgi_cache_key": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "fastcgi_cache_lock": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_cache_lock_age": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -251,7 +252,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxConf2More,
     },
     "fastcgi_cache_revalidate": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_cache_use_stale": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -266,13 +267,13 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "fastcgi_force_ranges": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_hide_header": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "fastcgi_ignore_client_abort": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_ignore_headers": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -281,10 +282,10 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "fastcgi_intercept_errors": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_keep_conn": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_limit_rate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -308,22 +309,22 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake23,
     },
     "fastcgi_pass": {
-        ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+        ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
     },
     "fastcgi_pass_header": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "fastcgi_pass_request_body": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_pass_request_headers": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_read_timeout": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "fastcgi_request_buffering": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_send_lowat": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -332,7 +333,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "fastcgi_socket_keepalive": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "fastcgi_split_path_info": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -372,7 +373,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxConfTake1,
     },
     "geoip_proxy_recursive": {
-        ngxHTTPMainConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxConfFlag | ngxConfUnique,
     },
     "google_perftools_profiles": {
         ngxMainConf | ngxDirectConf | ngxConfTake1,
@@ -393,7 +394,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "grpc_intercept_errors": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "grpc_next_upstream": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -405,7 +406,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "grpc_pass": {
-        ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+        ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
     },
     "grpc_pass_header": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -420,7 +421,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
     },
     "grpc_socket_keepalive": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "grpc_ssl_certificate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -447,34 +448,34 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "grpc_ssl_server_name": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "grpc_ssl_session_reuse": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "grpc_ssl_trusted_certificate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "grpc_ssl_verify": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "grpc_ssl_verify_depth": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "gunzip": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "gunzip_buffers": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
     },
     "gzip": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
     },
     "gzip_buffers": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
     },
     "gzip_comp_level": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "gzip_disable": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -495,17 +496,17 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "gzip_vary": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "hash": {
         ngxHTTPUpsConf | ngxConfTake12,
         ngxStreamUpsConf | ngxConfTake12,
     },
     "http": {
-        ngxMainConf | ngxConfBlock | ngxConfNoArgs,
+        ngxMainConf | ngxConfBlock | ngxConfNoArgs | ngxConfUnique,
     },
     "http2": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "http2_body_preread_size": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -535,7 +536,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "http2_push_preload": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "http2_recv_buffer_size": {
         ngxHTTPMainConf | ngxConfTake1,
@@ -544,10 +545,10 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
     },
     "http3": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "http3_hq": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "http3_max_concurrent_streams": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -562,7 +563,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "ignore_invalid_headers": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "image_filter": {
         ngxHTTPLocConf | ngxConfTake123,
@@ -571,7 +572,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "image_filter_interlace": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "image_filter_jpeg_quality": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -580,7 +581,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "image_filter_transparency": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "image_filter_webp_quality": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -601,7 +602,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "internal": {
-        ngxHTTPLocConf | ngxConfNoArgs,
+        ngxHTTPLocConf | ngxConfNoArgs | ngxConfUnique,
     },
     "ip_hash": {
         ngxHTTPUpsConf | ngxConfNoArgs,
@@ -613,19 +614,19 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
     },
     "keepalive_requests": {
-        ngxHTTPUpsConf | ngxConfTake1,
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPUpsConf | ngxConfTake1 | ngxConfUnique,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "keepalive_time": {
         ngxHTTPUpsConf | ngxConfTake1,
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "keepalive_timeout": {
-        ngxHTTPUpsConf | ngxConfTake1,
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
+        ngxHTTPUpsConf | ngxConfTake1 | ngxConfUnique,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12 | ngxConfUnique,
     },
     "large_client_header_buffers": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake2,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake2 | ngxConfUnique,
     },
     "least_conn": {
         ngxHTTPUpsConf | ngxConfNoArgs,
@@ -636,8 +637,8 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake2,
     },
     "limit_conn_dry_run": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "limit_conn_log_level": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -654,7 +655,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPLocConf | ngxConfBlock | ngxConf1More,
     },
     "limit_rate": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
     },
     "limit_rate_after": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
@@ -663,7 +664,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake123,
     },
     "limit_req_dry_run": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "limit_req_log_level": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -702,13 +703,13 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxConf2More,
     },
     "log_not_found": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "log_subrequest": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "mail": {
-        ngxMainConf | ngxConfBlock | ngxConfNoArgs,
+        ngxMainConf | ngxConfBlock | ngxConfNoArgs | ngxConfUnique,
     },
     "map": {
         ngxHTTPMainConf | ngxConfBlock | ngxConfTake2,
@@ -723,7 +724,7 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxConfTake1,
     },
     "master_process": {
-        ngxMainConf | ngxDirectConf | ngxConfFlag,
+        ngxMainConf | ngxDirectConf | ngxConfFlag | ngxConfUnique,
     },
     "max_errors": {
         ngxMailMainConf | ngxMailSrvConf | ngxConfTake1,
@@ -753,7 +754,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "memcached_pass": {
-        ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+        ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
     },
     "memcached_read_timeout": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -762,10 +763,10 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "memcached_socket_keepalive": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "merge_slashes": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "min_delete_depth": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -774,7 +775,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "mirror_request_body": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "modern_browser": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
@@ -792,22 +793,22 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "mp4_start_key_frame": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "msie_padding": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "msie_refresh": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "multi_accept": {
-        ngxEventConf | ngxConfFlag,
+        ngxEventConf | ngxConfFlag | ngxConfUnique,
     },
     "open_file_cache": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
     },
     "open_file_cache_errors": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "open_file_cache_min_uses": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -823,13 +824,13 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
     },
     "override_charset": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
     },
     "pass": {
         ngxStreamSrvConf | ngxConfTake1,
     },
     "pcre_jit": {
-        ngxMainConf | ngxDirectConf | ngxConfFlag,
+        ngxMainConf | ngxDirectConf | ngxConfFlag | ngxConfUnique,
     },
     "perl": {
         ngxHTTPLocConf | ngxHTTPLmtConf | ngxConfTake1,
@@ -844,7 +845,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxConfTake2,
     },
     "pid": {
-        ngxMainConf | ngxDirectConf | ngxConfTake1,
+        ngxMainConf | ngxDirectConf | ngxConfTake1 | ngxConfUnique,
     },
     "pop3_auth": {
         ngxMailMainConf | ngxMailSrvConf | ngxConf1More,
@@ -853,7 +854,7 @@ var oss126Directives = map[string][]uint{
         ngxMailMainConf | ngxMailSrvConf | ngxConf1More,
     },
     "port_in_redirect": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "postpone_output": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -875,35 +876,35 @@ var oss126Directives = map[string][]uint{
         ngxMailMainConf | ngxMailSrvConf | ngxConfTake1,
     },
     "proxy_buffer_size": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1 | ngxConfUnique,
     },
     "proxy_buffering": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_buffers": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2 | ngxConfUnique,
     },
     "proxy_busy_buffers_size": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "proxy_cache": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "proxy_cache_background_update": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_cache_bypass": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "proxy_cache_convert_head": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_cache_key": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "proxy_cache_lock": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_cache_lock_age": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -924,7 +925,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxConf2More,
     },
     "proxy_cache_revalidate": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_cache_use_stale": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -933,8 +934,8 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "proxy_connect_timeout": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1 | ngxConfUnique,
     },
     "proxy_cookie_domain": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
@@ -949,10 +950,10 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
     },
     "proxy_force_ranges": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_half_close": {
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_headers_hash_bucket_size": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -964,16 +965,16 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "proxy_http_version": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "proxy_ignore_client_abort": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_ignore_headers": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "proxy_intercept_errors": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_limit_rate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -986,7 +987,7 @@ var oss126Directives = map[string][]uint{
     },
     "proxy_next_upstream": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_next_upstream_timeout": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1000,36 +1001,36 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "proxy_pass": {
-        ngxHTTPLocConf | ngxHTTPLifConf | ngxHTTPLmtConf | ngxConfTake1,
-        ngxStreamSrvConf | ngxConfTake1,
+        ngxHTTPLocConf | ngxHTTPLifConf | ngxHTTPLmtConf | ngxConfTake1 | ngxConfUnique,
+        ngxStreamSrvConf | ngxConfTake1 | ngxConfUnique,
     },
     "proxy_pass_error_message": {
-        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
+        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_pass_header": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "proxy_pass_request_body": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_pass_request_headers": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_protocol": {
-        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_protocol_timeout": {
         ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
     },
     "proxy_read_timeout": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "proxy_redirect": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake12,
     },
     "proxy_request_buffering": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_requests": {
         ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
@@ -1041,7 +1042,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "proxy_send_timeout": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "proxy_set_body": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1050,14 +1051,14 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
     },
     "proxy_smtp_auth": {
-        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
+        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_socket_keepalive": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_ssl": {
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_ssl_certificate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1092,20 +1093,20 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxStreamSrvConf | ngxConf1More,
     },
     "proxy_ssl_server_name": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_ssl_session_reuse": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_ssl_trusted_certificate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
         ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
     },
     "proxy_ssl_verify": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "proxy_ssl_verify_depth": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1134,23 +1135,23 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
     },
     "quic_bpf": {
-        ngxMainConf | ngxDirectConf | ngxConfFlag,
+        ngxMainConf | ngxDirectConf | ngxConfFlag | ngxConfUnique,
     },
     "quic_gso": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "quic_host_key": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
     },
     "quic_retry": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "random": {
         ngxHTTPUpsConf | ngxConfNoArgs | ngxConfTake12,
         ngxStreamUpsConf | ngxConfNoArgs | ngxConfTake12,
     },
     "random_index": {
-        ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "read_ahead": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1159,10 +1160,10 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "real_ip_recursive": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "recursive_error_pages": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "referer_hash_bucket_size": {
         ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1174,7 +1175,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
     },
     "reset_timedout_connection": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "resolver": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -1194,10 +1195,10 @@ var oss126Directives = map[string][]uint{
         ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake23,
     },
     "rewrite_log": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
     },
     "root": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
     },
     "satisfy": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1209,7 +1210,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "scgi_buffering": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "scgi_buffers": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
@@ -1221,16 +1222,16 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "scgi_cache_background_update": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "scgi_cache_bypass": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "scgi_cache_key": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "scgi_cache_lock": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "scgi_cache_lock_age": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1251,7 +1252,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxConf2More,
     },
     "scgi_cache_revalidate": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "scgi_cache_use_stale": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -1263,19 +1264,19 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "scgi_force_ranges": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "scgi_hide_header": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "scgi_ignore_client_abort": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "scgi_ignore_headers": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "scgi_intercept_errors": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "scgi_limit_rate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1299,28 +1300,28 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake23,
     },
     "scgi_pass": {
-        ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+        ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
     },
     "scgi_pass_header": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "scgi_pass_request_body": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "scgi_pass_request_headers": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "scgi_read_timeout": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "scgi_request_buffering": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "scgi_send_timeout": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "scgi_socket_keepalive": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "scgi_store": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1347,10 +1348,10 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "send_timeout": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "sendfile": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
     },
     "sendfile_max_chunk": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1368,18 +1369,18 @@ var oss126Directives = map[string][]uint{
         ngxStreamSrvConf | ngxConf1More,
     },
     "server_name_in_redirect": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "server_names_hash_bucket_size": {
-        ngxHTTPMainConf | ngxConfTake1,
-        ngxStreamMainConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxConfTake1 | ngxConfUnique,
+        ngxStreamMainConf | ngxConfTake1 | ngxConfUnique,
     },
     "server_names_hash_max_size": {
-        ngxHTTPMainConf | ngxConfTake1,
-        ngxStreamMainConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxConfTake1 | ngxConfUnique,
+        ngxStreamMainConf | ngxConfTake1 | ngxConfUnique,
     },
     "server_tokens": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "set": {
         ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake2,
@@ -1413,16 +1414,16 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxConfBlock | ngxConfTake2,
     },
     "ssi": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
     },
     "ssi_last_modified": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "ssi_min_file_chunk": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "ssi_silent_errors": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "ssi_types": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -1447,9 +1448,9 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
     },
     "ssl_ciphers": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
-        ngxMailMainConf | ngxMailSrvConf | ngxConfTake1,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1 | ngxConfUnique,
+        ngxMailMainConf | ngxMailSrvConf | ngxConfTake1 | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1 | ngxConfUnique,
     },
     "ssl_client_certificate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -1472,7 +1473,7 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
     },
     "ssl_early_data": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "ssl_ecdh_curve": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -1486,7 +1487,7 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
     },
     "ssl_ocsp": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "ssl_ocsp_cache": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -1500,12 +1501,12 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
     },
     "ssl_prefer_server_ciphers": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
-        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
+        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "ssl_preread": {
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "ssl_protocols": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConf1More,
@@ -1513,8 +1514,8 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxStreamSrvConf | ngxConf1More,
     },
     "ssl_reject_handshake": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "ssl_session_cache": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake12,
@@ -1527,17 +1528,17 @@ var oss126Directives = map[string][]uint{
         ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
     },
     "ssl_session_tickets": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
-        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
+        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "ssl_session_timeout": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
-        ngxMailMainConf | ngxMailSrvConf | ngxConfTake1,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1 | ngxConfUnique,
+        ngxMailMainConf | ngxMailSrvConf | ngxConfTake1 | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfTake1 | ngxConfUnique,
     },
     "ssl_stapling": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "ssl_stapling_file": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -1546,7 +1547,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
     },
     "ssl_stapling_verify": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "ssl_trusted_certificate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfTake1,
@@ -1567,7 +1568,7 @@ var oss126Directives = map[string][]uint{
         ngxMailMainConf | ngxMailSrvConf | ngxConfTake1,
     },
     "stream": {
-        ngxMainConf | ngxConfBlock | ngxConfNoArgs,
+        ngxMainConf | ngxConfBlock | ngxConfNoArgs | ngxConfUnique,
     },
     "stub_status": {
         ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfNoArgs | ngxConfTake1,
@@ -1576,10 +1577,10 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
     },
     "sub_filter_last_modified": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "sub_filter_once": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "sub_filter_types": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -1588,11 +1589,11 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "tcp_nodelay": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
-        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
+        ngxStreamMainConf | ngxStreamSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "tcp_nopush": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "thread_pool": {
         ngxMainConf | ngxDirectConf | ngxConfTake23,
@@ -1604,7 +1605,7 @@ var oss126Directives = map[string][]uint{
         ngxMainConf | ngxDirectConf | ngxConfTake1,
     },
     "try_files": {
-        ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf2More,
+        ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf2More | ngxConfUnique,
     },
     "types": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfBlock | ngxConfNoArgs,
@@ -1613,13 +1614,13 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "types_hash_max_size": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "underscores_in_headers": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "uninitialized_variable_warn": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPSifConf | ngxHTTPLocConf | ngxHTTPLifConf | ngxConfFlag | ngxConfUnique,
     },
     "upstream": {
         ngxHTTPMainConf | ngxConfBlock | ngxConfTake1,
@@ -1629,7 +1630,7 @@ var oss126Directives = map[string][]uint{
         ngxEventConf | ngxConfTake1,
     },
     "user": {
-        ngxMainConf | ngxDirectConf | ngxConfTake12,
+        ngxMainConf | ngxDirectConf | ngxConfTake12 | ngxConfUnique,
     },
     "userid": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1665,7 +1666,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "uwsgi_buffering": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_buffers": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
@@ -1683,10 +1684,10 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "uwsgi_cache_key": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1 | ngxConfUnique,
     },
     "uwsgi_cache_lock": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_cache_lock_age": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1707,7 +1708,7 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxConf2More,
     },
     "uwsgi_cache_revalidate": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_cache_use_stale": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
@@ -1719,19 +1720,19 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "uwsgi_force_ranges": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_hide_header": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "uwsgi_ignore_client_abort": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_ignore_headers": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "uwsgi_intercept_errors": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_limit_rate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1761,28 +1762,28 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake23,
     },
     "uwsgi_pass": {
-        ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1,
+        ngxHTTPLocConf | ngxHTTPLifConf | ngxConfTake1 | ngxConfUnique,
     },
     "uwsgi_pass_header": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "uwsgi_pass_request_body": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_pass_request_headers": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_read_timeout": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "uwsgi_request_buffering": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_send_timeout": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "uwsgi_socket_keepalive": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_ssl_certificate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1809,16 +1810,16 @@ var oss126Directives = map[string][]uint{
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConf1More,
     },
     "uwsgi_ssl_server_name": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_ssl_session_reuse": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_ssl_trusted_certificate": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "uwsgi_ssl_verify": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "uwsgi_ssl_verify_depth": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
@@ -1850,7 +1851,7 @@ var oss126Directives = map[string][]uint{
         ngxEventConf | ngxConfTake1,
     },
     "worker_connections": {
-        ngxEventConf | ngxConfTake1,
+        ngxEventConf | ngxConfTake1 | ngxConfUnique,
     },
     "worker_cpu_affinity": {
         ngxMainConf | ngxDirectConf | ngxConf1More,
@@ -1859,13 +1860,13 @@ var oss126Directives = map[string][]uint{
         ngxMainConf | ngxDirectConf | ngxConfTake1,
     },
     "worker_processes": {
-        ngxMainConf | ngxDirectConf | ngxConfTake1,
+        ngxMainConf | ngxDirectConf | ngxConfTake1 | ngxConfUnique,
     },
     "worker_rlimit_core": {
         ngxMainConf | ngxDirectConf | ngxConfTake1,
     },
     "worker_rlimit_nofile": {
-        ngxMainConf | ngxDirectConf | ngxConfTake1,
+        ngxMainConf | ngxDirectConf | ngxConfTake1 | ngxConfUnique,
     },
     "worker_shutdown_timeout": {
         ngxMainConf | ngxDirectConf | ngxConfTake1,
@@ -1874,13 +1875,13 @@ var oss126Directives = map[string][]uint{
         ngxMainConf | ngxDirectConf | ngxConfTake1,
     },
     "xclient": {
-        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag,
+        ngxMailMainConf | ngxMailSrvConf | ngxConfFlag | ngxConfUnique,
     },
     "xml_entities": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake1,
     },
     "xslt_last_modified": {
-        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag,
+        ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfFlag | ngxConfUnique,
     },
     "xslt_param": {
         ngxHTTPMainConf | ngxHTTPSrvConf | ngxHTTPLocConf | ngxConfTake2,
@@ -1905,3 +1906,8 @@ func MatchOss126(directive string) ([]uint, bool) {
     m, ok := oss126Directives[directive]
     return m, ok
 }
+
+// Oss126Registry returns a Registry with the directives of MatchOss126.
+func Oss126Registry() *Registry {
+    return registryFromMasks("nginx", oss126Directives)
+}
@@ -11,6 +11,7 @@
 //   - how many arguments the directive can take
 //   - whether or not it is a block directive
 //   - whether this is a flag (takes one argument that's either "on" or "off")
+//   - whether it may appear only once in a block
 //   - which contexts it's allowed to be in
 
 package crossplane
@@ -41,3 +42,8 @@ func MatchOtelLatest(directive string) ([]uint, bool) {
     m, ok := otelDirectives[directive]
     return m, ok
 }
+
+// OtelLatestRegistry returns a Registry with the directives of MatchOtelLatest.
+func OtelLatestRegistry() *Registry {
+    return registryFromMasks("otel", otelDirectives)
+}
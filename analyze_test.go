@@ -8,6 +8,7 @@
 package crossplane
 
 import (
+	"io"
 	"strings"
 	"testing"
 )
@@ -2795,3 +2796,107 @@ func TestAnalyze_geoip2(t *testing.T) {
 		})
 	}
 }
+
+func TestEnterBlockCtx(t *testing.T) {
+	t.Parallel()
+	custom := []BlockContext{
+		{Path: []string{"http", "server", "my_zone"}, Mask: CustomContext(0), Nested: true},
+		{Path: []string{"http", "location"}, Mask: ContextHTTPLocation},
+	}
+	testcases := map[string]struct {
+		directive string
+		ctx       blockCtx
+		custom    []BlockContext
+		want      string
+	}{
+		"location in http":          {"location", blockCtx{"http"}, nil, "http>location"},
+		"location in server":        {"location", blockCtx{"http", "server"}, nil, "http>location"},
+		"location in location":      {"location", blockCtx{"http", "location"}, nil, "http>location"},
+		"server in http":            {"server", blockCtx{"http"}, nil, "http>server"},
+		"my_zone in server":         {"my_zone", blockCtx{"http", "server"}, custom, "http>server>my_zone"},
+		"my_zone in location":       {"my_zone", blockCtx{"http", "server", "location"}, custom, "http>server>my_zone"},
+		"my_zone in http":           {"my_zone", blockCtx{"http"}, custom, "http>my_zone"},
+		"custom rules come first":   {"location", blockCtx{"http", "server"}, custom, "http>location"},
+		"location outside of http":  {"location", blockCtx{"my_block"}, nil, "my_block>location"},
+		"mgmt in main":              {"mgmt", blockCtx{}, nil, "mgmt"},
+		"nested if keeps appending": {"if", blockCtx{"http", "location"}, nil, "http>location>if"},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := enterBlockCtx(&Directive{Directive: tc.directive}, tc.ctx, tc.custom).key()
+			if got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCustomBlockContexts(t *testing.T) {
+	t.Parallel()
+	myZone := CustomContext(0)
+	registry := ComposeRegistries(DefaultRegistry(), NewRegistry(
+		DirectiveSpec{Name: "my_zone", Contexts: ContextHTTPServer, Args: Args1, Block: true},
+		DirectiveSpec{Name: "my_size", Contexts: myZone, Args: Args1},
+		DirectiveSpec{Name: "my_enabled", Contexts: myZone | ContextHTTPLocation, Args: ArgsFlag},
+	))
+	config := `http {
+    server {
+        my_zone one {
+            my_size 10m;
+            my_enabled maybe;
+            listen 80;
+        }
+        my_size 1m;
+    }
+}
+`
+	testcases := map[string]struct {
+		contexts []BlockContext
+		errs     []string
+	}{
+		"unknown context is not checked": {
+			nil,
+			[]string{`"my_size" directive is not allowed here in nginx.conf:8`},
+		},
+		"custom context": {
+			[]BlockContext{{Path: []string{"http", "server", "my_zone"}, Mask: myZone}},
+			[]string{
+				`invalid value "maybe" in "my_enabled" directive, it must be "on" or "off" in nginx.conf:5`,
+				`"listen" directive is not allowed here in nginx.conf:6`,
+				`"my_size" directive is not allowed here in nginx.conf:8`,
+			},
+		},
+		"checked like a location": {
+			[]BlockContext{{Path: []string{"http", "server", "my_zone"}, Mask: ContextHTTPLocation}},
+			[]string{
+				`"my_size" directive is not allowed here in nginx.conf:4`,
+				`invalid value "maybe" in "my_enabled" directive, it must be "on" or "off" in nginx.conf:5`,
+				`"listen" directive is not allowed here in nginx.conf:6`,
+				`"my_size" directive is not allowed here in nginx.conf:8`,
+			},
+		},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			payload, err := Parse("nginx.conf", &ParseOptions{
+				Open:             func(string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(config)), nil },
+				DirectiveSources: []MatchFunc{registry.MatchFunc()},
+				BlockContexts:    tc.contexts,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var errs []string
+			for _, e := range payload.Errors {
+				errs = append(errs, e.Error.Error())
+			}
+			if strings.Join(errs, "\n") != strings.Join(tc.errs, "\n") {
+				t.Fatalf("expected errors:\n%s\ngot:\n%s", strings.Join(tc.errs, "\n"), strings.Join(errs, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ArgKind is the kind of value a directive takes, as told by the function NGINX sets it with.
+type ArgKind string
+
+// The ArgKinds of the set functions of NGINX, like ngx_conf_set_flag_slot for ArgKindFlag.
+const (
+	ArgKindFlag         ArgKind = "flag"          // "on" or "off"
+	ArgKindString       ArgKind = "string"        // any string
+	ArgKindStringArray  ArgKind = "string_array"  // a string, the directive may repeat
+	ArgKindKeyval       ArgKind = "keyval"        // a key and a value
+	ArgKindNumber       ArgKind = "number"        // a non-negative integer
+	ArgKindSize         ArgKind = "size"          // a size, like 8k or 1m
+	ArgKindOffset       ArgKind = "offset"        // a size that may be in gigabytes, like 2g
+	ArgKindMsec         ArgKind = "msec"          // a time, in milliseconds if it has no unit
+	ArgKindSec          ArgKind = "sec"           // a time, in seconds if it has no unit
+	ArgKindBufs         ArgKind = "bufs"          // a number and a size, like 8 4k
+	ArgKindEnum         ArgKind = "enum"          // one of Values
+	ArgKindBitmask      ArgKind = "bitmask"       // some of Values
+	ArgKindPath         ArgKind = "path"          // a path and the levels of its subdirectories
+	ArgKindAccess       ArgKind = "access"        // file permissions, like user:rw group:r
+	ArgKindComplexValue ArgKind = "complex_value" // a string with variables
+	ArgKindCustom       ArgKind = "custom"        // parsed by a function of the module
+)
+
+// ArgType describes the value of a directive, as found in its ngx_command_t in the source
+// of NGINX or of a module. Use Check to validate the arguments of a directive with it.
+type ArgType struct {
+	Kind ArgKind `json:"kind" yaml:"kind"`
+
+	// Handler is the function that sets the directive, like "ngx_conf_set_flag_slot".
+	Handler string `json:"handler,omitempty" yaml:"handler,omitempty"`
+
+	// Conf is the configuration the directive is stored in, like "NGX_HTTP_LOC_CONF_OFFSET",
+	// and Offset the field of it, like "keepalive_timeout".
+	Conf   string `json:"conf,omitempty" yaml:"conf,omitempty"`
+	Offset string `json:"offset,omitempty" yaml:"offset,omitempty"`
+
+	// Post is the post handler of the directive, or the table of its values.
+	Post string `json:"post,omitempty" yaml:"post,omitempty"`
+
+	// Values holds the values of ArgKindEnum and ArgKindBitmask.
+	Values []string `json:"values,omitempty" yaml:"values,omitempty"`
+
+	// Bounds holds the minimum and maximum of an ArgKindNumber checked by
+	// ngx_conf_check_num_bounds. A maximum of -1 is no maximum.
+	Bounds []int64 `json:"bounds,omitempty" yaml:"bounds,omitempty"`
+}
+
+//nolint:gochecknoglobals
+var (
+	sizeRegexp   = regexp.MustCompile(`^\d+[kKmM]?$`)
+	offsetRegexp = regexp.MustCompile(`^\d+[kKmMgG]?$`)
+	timeRegexp   = regexp.MustCompile(`^(\d+(ms|[yMwdhms])?\s*)+$`)
+)
+
+// Check returns an error if the arguments of a directive aren't valid values for t, like
+// NGINX does when it reads the config. The arguments of the kinds that are parsed by the
+// module itself, or are any string, aren't checked.
+func (t ArgType) Check(directive string, args []string) error {
+	invalid := func(arg string, must string) error {
+		if must == "" {
+			return fmt.Errorf(`invalid value "%s" in "%s" directive`, arg, directive)
+		}
+		return fmt.Errorf(`invalid value "%s" in "%s" directive, it must be %s`, arg, directive, must)
+	}
+
+	switch t.Kind {
+	case ArgKindFlag:
+		if len(args) == 1 && !strings.EqualFold(args[0], "on") && !strings.EqualFold(args[0], "off") {
+			return invalid(args[0], `"on" or "off"`)
+		}
+	case ArgKindEnum, ArgKindBitmask:
+		for _, arg := range args {
+			if !containsFold(t.Values, arg) {
+				return invalid(arg, "one of "+strings.Join(t.Values, ", "))
+			}
+		}
+	case ArgKindNumber:
+		for _, arg := range args {
+			n, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil || n < 0 {
+				return invalid(arg, "")
+			}
+			if len(t.Bounds) == 2 && (n < t.Bounds[0] || (t.Bounds[1] != -1 && n > t.Bounds[1])) {
+				if t.Bounds[1] == -1 {
+					return invalid(arg, fmt.Sprintf("equal to or greater than %d", t.Bounds[0]))
+				}
+				return invalid(arg, fmt.Sprintf("between %d and %d", t.Bounds[0], t.Bounds[1]))
+			}
+		}
+	case ArgKindSize:
+		for _, arg := range args {
+			if !sizeRegexp.MatchString(arg) {
+				return invalid(arg, "")
+			}
+		}
+	case ArgKindOffset:
+		for _, arg := range args {
+			if !offsetRegexp.MatchString(arg) {
+				return invalid(arg, "")
+			}
+		}
+	case ArgKindMsec, ArgKindSec:
+		for _, arg := range args {
+			if !timeRegexp.MatchString(arg) {
+				return invalid(arg, "")
+			}
+		}
+	case ArgKindBufs:
+		if len(args) == 2 {
+			if _, err := strconv.ParseUint(args[0], 10, 64); err != nil || args[0] == "0" {
+				return invalid(args[0], "")
+			}
+			if !sizeRegexp.MatchString(args[1]) {
+				return invalid(args[1], "")
+			}
+		}
+	}
+	return nil
+}
+
+// Repeatable reports whether a directive of type t may appear more than once in a block.
+// The set functions of NGINX reject a second value with "directive is duplicate", but
+// those of ArgKindStringArray, ArgKindKeyval and ArgKindBitmask add to the first one.
+// Directives parsed by the module itself are assumed to be repeatable.
+func (t ArgType) Repeatable() bool {
+	switch t.Kind {
+	case ArgKindStringArray, ArgKindKeyval, ArgKindBitmask, ArgKindCustom:
+		return true
+	case ArgKindFlag, ArgKindString, ArgKindNumber, ArgKindSize, ArgKindOffset, ArgKindMsec, ArgKindSec,
+		ArgKindBufs, ArgKindEnum, ArgKindPath, ArgKindAccess, ArgKindComplexValue:
+		return false
+	}
+	return true
+}
+
+func containsFold(xs []string, x string) bool {
+	for _, s := range xs {
+		if strings.EqualFold(s, x) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestArgTypeCheck(t *testing.T) {
+	t.Parallel()
+	for name, tc := range map[string]struct {
+		argType ArgType
+		args    []string
+		err     string
+	}{
+		"flag":            {ArgType{Kind: ArgKindFlag}, []string{"On"}, ""},
+		"flag invalid":    {ArgType{Kind: ArgKindFlag}, []string{"yes"}, `invalid value "yes" in "d" directive, it must be "on" or "off"`},
+		"enum":            {ArgType{Kind: ArgKindEnum, Values: []string{"off", "on", "clean"}}, []string{"clean"}, ""},
+		"enum invalid":    {ArgType{Kind: ArgKindEnum, Values: []string{"off", "on"}}, []string{"all"}, `invalid value "all" in "d" directive, it must be one of off, on`},
+		"bitmask":         {ArgType{Kind: ArgKindBitmask, Values: []string{"error", "timeout"}}, []string{"error", "timeout"}, ""},
+		"bitmask invalid": {ArgType{Kind: ArgKindBitmask, Values: []string{"error", "timeout"}}, []string{"error", "http_500"}, `invalid value "http_500" in "d" directive, it must be one of error, timeout`},
+		"number":          {ArgType{Kind: ArgKindNumber, Bounds: []int64{1, 9}}, []string{"9"}, ""},
+		"number bounds":   {ArgType{Kind: ArgKindNumber, Bounds: []int64{1, 9}}, []string{"10"}, `invalid value "10" in "d" directive, it must be between 1 and 9`},
+		"number minimum":  {ArgType{Kind: ArgKindNumber, Bounds: []int64{1, -1}}, []string{"0"}, `invalid value "0" in "d" directive, it must be equal to or greater than 1`},
+		"number invalid":  {ArgType{Kind: ArgKindNumber}, []string{"-1"}, `invalid value "-1" in "d" directive`},
+		"size":            {ArgType{Kind: ArgKindSize}, []string{"8k"}, ""},
+		"size invalid":    {ArgType{Kind: ArgKindSize}, []string{"2g"}, `invalid value "2g" in "d" directive`},
+		"offset":          {ArgType{Kind: ArgKindOffset}, []string{"2g"}, ""},
+		"msec":            {ArgType{Kind: ArgKindMsec}, []string{"1m 30s"}, ""},
+		"sec invalid":     {ArgType{Kind: ArgKindSec}, []string{"soon"}, `invalid value "soon" in "d" directive`},
+		"bufs":            {ArgType{Kind: ArgKindBufs}, []string{"8", "4k"}, ""},
+		"bufs invalid":    {ArgType{Kind: ArgKindBufs}, []string{"0", "4k"}, `invalid value "0" in "d" directive`},
+		"custom":          {ArgType{Kind: ArgKindCustom}, []string{"anything"}, ""},
+		"string":          {ArgType{Kind: ArgKindString}, []string{"anything"}, ""},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.argType.Check("d", tc.args)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestSpecFileArgTypes(t *testing.T) {
+	t.Parallel()
+	spec := `directives:
+  - name: my_level
+    contexts: [http]
+    args: [take1]
+    argType:
+      kind: number
+      handler: ngx_conf_set_num_slot
+      post: my_level_bounds
+      bounds: [1, 9]
+  - name: my_level
+    contexts: [stream]
+    args: [take1]
+  - name: my_zone
+    contexts: [http]
+    args: [take2]
+  - name: my_header
+    contexts: [http]
+    args: [take1]
+    argType:
+      kind: string_array
+      handler: ngx_conf_set_str_array_slot
+`
+	var f SpecFile
+	require.NoError(t, yaml.NewDecoder(strings.NewReader(spec)).Decode(&f))
+	require.Equal(t, map[string][]ArgType{
+		"my_level":  {{Kind: ArgKindNumber, Handler: "ngx_conf_set_num_slot", Post: "my_level_bounds", Bounds: []int64{1, 9}}},
+		"my_header": {{Kind: ArgKindStringArray, Handler: "ngx_conf_set_str_array_slot"}},
+	}, f.ArgTypes())
+
+	// a directive whose set function rejects duplicates is unique
+	registry, err := LoadRegistry(strings.NewReader(spec))
+	require.NoError(t, err)
+	unique := map[string][]bool{}
+	for _, s := range registry.Specs() {
+		unique[s.Name] = append(unique[s.Name], s.Unique)
+	}
+	require.Equal(t, map[string][]bool{"my_level": {true, false}, "my_zone": {false}, "my_header": {false}}, unique)
+}
+
+func TestArgTypeRepeatable(t *testing.T) {
+	t.Parallel()
+	require.False(t, ArgType{Kind: ArgKindFlag}.Repeatable())
+	require.False(t, ArgType{Kind: ArgKindComplexValue}.Repeatable())
+	require.True(t, ArgType{Kind: ArgKindStringArray}.Repeatable())
+	require.True(t, ArgType{Kind: ArgKindBitmask}.Repeatable())
+	require.True(t, ArgType{Kind: ArgKindCustom}.Repeatable())
+}
@@ -0,0 +1,594 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The binary encoding of a Payload is a compact alternative to its JSON form for sending
+// large configs over slow links. A stream starts with binaryMagic and binaryVersion,
+// followed by any number of payloads. All integers are varints. Directive names, file
+// paths and statuses are interned: the first time one of them is written it is sent in
+// full and added to a table, after which it is sent as its index in the table.
+// The table is shared by every payload in the stream. The line of each directive is sent
+// as the difference from the line of the directive before it in the same config.
+const (
+	binaryMagic   = "NGXB"
+	binaryVersion = 1
+
+	// binaryMaxLen limits the length of strings and lists a decoder accepts, so that a
+	// corrupt stream can't make it allocate huge amounts of memory.
+	binaryMaxLen = 1 << 26
+)
+
+// directive flags
+const (
+	binaryFlagFile = 1 << iota
+	binaryFlagBlock
+	binaryFlagComment
+	binaryFlagIncludes
+	binaryFlagLeadingComments
+	binaryFlagTrailingComment
+	binaryFlagArgComments
+	binaryFlagFormatting
+	binaryFlagCommentBlankLines
+)
+
+// ErrInvalidBinary is returned by BinaryDecoder when the stream isn't in the binary
+// encoding of a Payload.
+//
+//nolint:gochecknoglobals
+var ErrInvalidBinary = errors.New("invalid binary payload")
+
+// BinaryEncoder writes payloads to a stream in the binary encoding.
+type BinaryEncoder struct {
+	w       *bufio.Writer
+	strings map[string]uint64
+	started bool
+	buf     [binary.MaxVarintLen64]byte
+	line    int
+}
+
+// NewBinaryEncoder returns an encoder that writes to w.
+func NewBinaryEncoder(w io.Writer) *BinaryEncoder {
+	return &BinaryEncoder{w: bufio.NewWriter(w), strings: map[string]uint64{}}
+}
+
+// Encode writes a payload to the stream. The Callback of payload errors is not encoded.
+func (e *BinaryEncoder) Encode(payload *Payload) error {
+	if !e.started {
+		e.started = true
+		e.w.WriteString(binaryMagic)
+		e.uint(binaryVersion)
+	}
+
+	e.intern(payload.Status)
+	e.uint(uint64(len(payload.Errors)))
+	for _, perr := range payload.Errors {
+		e.intern(perr.File)
+		e.optionalInt(perr.Line)
+		e.string(errorString(perr.Error))
+	}
+	e.uint(uint64(len(payload.Config)))
+	for i := range payload.Config {
+		e.config(&payload.Config[i])
+	}
+	return e.w.Flush()
+}
+
+func (e *BinaryEncoder) config(config *Config) {
+	e.intern(config.File)
+	e.intern(config.Status)
+	e.uint(uint64(len(config.Errors)))
+	for _, cerr := range config.Errors {
+		e.optionalInt(cerr.Line)
+		e.string(errorString(cerr.Error))
+	}
+	e.line = 0
+	e.bool(config.Parsed != nil)
+	e.directives(config.Parsed)
+}
+
+func (e *BinaryEncoder) directives(d Directives) {
+	e.uint(uint64(len(d)))
+	for _, stmt := range d {
+		var flags uint64
+		if stmt.File != "" {
+			flags |= binaryFlagFile
+		}
+		if stmt.IsBlock() {
+			flags |= binaryFlagBlock
+		}
+		if stmt.Comment != nil {
+			flags |= binaryFlagComment
+		}
+		if stmt.Includes != nil {
+			flags |= binaryFlagIncludes
+		}
+		if len(stmt.LeadingComments) > 0 {
+			flags |= binaryFlagLeadingComments
+		}
+		if stmt.TrailingComment != nil {
+			flags |= binaryFlagTrailingComment
+		}
+		if len(stmt.ArgComments) > 0 {
+			flags |= binaryFlagArgComments
+		}
+		if stmt.BlankLinesBefore != 0 || len(stmt.ArgBreaks) > 0 {
+			flags |= binaryFlagFormatting
+		}
+		if len(stmt.LeadingCommentBlankLines) > 0 {
+			flags |= binaryFlagCommentBlankLines
+		}
+
+		e.intern(stmt.Directive)
+		e.int(stmt.Line - e.line)
+		e.line = stmt.Line
+		e.uint(flags)
+		e.uint(uint64(len(stmt.Args)))
+		for _, arg := range stmt.Args {
+			e.string(arg)
+		}
+		if flags&binaryFlagFile != 0 {
+			e.intern(stmt.File)
+		}
+		if flags&binaryFlagComment != 0 {
+			e.string(*stmt.Comment)
+		}
+		if flags&binaryFlagIncludes != 0 {
+			e.uint(uint64(len(stmt.Includes)))
+			for _, i := range stmt.Includes {
+				e.uint(uint64(i))
+			}
+		}
+		if flags&binaryFlagLeadingComments != 0 {
+			e.uint(uint64(len(stmt.LeadingComments)))
+			for _, c := range stmt.LeadingComments {
+				e.string(c)
+			}
+		}
+		if flags&binaryFlagTrailingComment != 0 {
+			e.string(*stmt.TrailingComment)
+		}
+		if flags&binaryFlagArgComments != 0 {
+			e.uint(uint64(len(stmt.ArgComments)))
+			for _, c := range stmt.ArgComments {
+				e.uint(uint64(c.Index))
+				e.string(c.Comment)
+			}
+		}
+		if flags&binaryFlagFormatting != 0 {
+			e.uint(uint64(stmt.BlankLinesBefore))
+			e.uint(uint64(len(stmt.ArgBreaks)))
+			for _, i := range stmt.ArgBreaks {
+				e.uint(uint64(i))
+			}
+		}
+		if flags&binaryFlagCommentBlankLines != 0 {
+			e.uint(uint64(len(stmt.LeadingCommentBlankLines)))
+			for _, n := range stmt.LeadingCommentBlankLines {
+				e.uint(uint64(n))
+			}
+		}
+		if flags&binaryFlagBlock != 0 {
+			e.directives(stmt.Block)
+		}
+	}
+}
+
+func (e *BinaryEncoder) uint(v uint64) {
+	n := binary.PutUvarint(e.buf[:], v)
+	e.w.Write(e.buf[:n])
+}
+
+func (e *BinaryEncoder) int(v int) {
+	n := binary.PutVarint(e.buf[:], int64(v))
+	e.w.Write(e.buf[:n])
+}
+
+func (e *BinaryEncoder) bool(v bool) {
+	if v {
+		e.uint(1)
+	} else {
+		e.uint(0)
+	}
+}
+
+// optionalInt writes 0 for nil and the value plus one otherwise.
+func (e *BinaryEncoder) optionalInt(v *int) {
+	if v == nil {
+		e.uint(0)
+		return
+	}
+	e.uint(uint64(*v) + 1)
+}
+
+func (e *BinaryEncoder) string(s string) {
+	e.uint(uint64(len(s)))
+	e.w.WriteString(s)
+}
+
+// intern writes 0 followed by the string if it hasn't been written before, and its index
+// in the table plus one otherwise.
+func (e *BinaryEncoder) intern(s string) {
+	if i, ok := e.strings[s]; ok {
+		e.uint(i + 1)
+		return
+	}
+	e.strings[s] = uint64(len(e.strings))
+	e.uint(0)
+	e.string(s)
+}
+
+// BinaryDecoder reads payloads from a stream in the binary encoding.
+type BinaryDecoder struct {
+	r       *bufio.Reader
+	strings []string
+	started bool
+	line    int
+}
+
+// NewBinaryDecoder returns a decoder that reads from r.
+func NewBinaryDecoder(r io.Reader) *BinaryDecoder {
+	return &BinaryDecoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next payload from the stream. It returns io.EOF when the stream has
+// no more payloads.
+func (d *BinaryDecoder) Decode() (*Payload, error) {
+	if _, err := d.r.Peek(1); err != nil {
+		return nil, err
+	}
+	if err := d.header(); err != nil {
+		return nil, err
+	}
+
+	payload, err := d.payload()
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding binary payload: %w", err)
+	}
+	return payload, nil
+}
+
+func (d *BinaryDecoder) header() error {
+	if d.started {
+		return nil
+	}
+	d.started = true
+	magic := make([]byte, len(binaryMagic))
+	if _, err := io.ReadFull(d.r, magic); err != nil || string(magic) != binaryMagic {
+		return ErrInvalidBinary
+	}
+	version, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return ErrInvalidBinary
+	}
+	if version != binaryVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidBinary, version)
+	}
+	return nil
+}
+
+//nolint:gocognit
+func (d *BinaryDecoder) payload() (*Payload, error) {
+	var err error
+	p := &Payload{}
+	if p.Status, err = d.intern(); err != nil {
+		return nil, err
+	}
+
+	n, err := d.len()
+	if err != nil {
+		return nil, err
+	}
+	p.Errors = []PayloadError{}
+	for i := 0; i < n; i++ {
+		var perr PayloadError
+		if perr.File, err = d.intern(); err != nil {
+			return nil, err
+		}
+		if perr.Line, err = d.optionalInt(); err != nil {
+			return nil, err
+		}
+		msg, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		perr.Error = decodeError(msg)
+		p.Errors = append(p.Errors, perr)
+	}
+
+	if n, err = d.len(); err != nil {
+		return nil, err
+	}
+	p.Config = []Config{}
+	for i := 0; i < n; i++ {
+		config, err := d.config()
+		if err != nil {
+			return nil, err
+		}
+		p.Config = append(p.Config, *config)
+	}
+	return p, nil
+}
+
+func (d *BinaryDecoder) config() (*Config, error) {
+	var err error
+	c := &Config{}
+	if c.File, err = d.intern(); err != nil {
+		return nil, err
+	}
+	if c.Status, err = d.intern(); err != nil {
+		return nil, err
+	}
+
+	n, err := d.len()
+	if err != nil {
+		return nil, err
+	}
+	c.Errors = []ConfigError{}
+	for i := 0; i < n; i++ {
+		var cerr ConfigError
+		if cerr.Line, err = d.optionalInt(); err != nil {
+			return nil, err
+		}
+		msg, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		cerr.Error = decodeError(msg)
+		c.Errors = append(c.Errors, cerr)
+	}
+
+	d.line = 0
+	parsed, err := d.uint()
+	if err != nil {
+		return nil, err
+	}
+	if c.Parsed, err = d.directives(); err != nil {
+		return nil, err
+	}
+	if parsed != 0 && c.Parsed == nil {
+		c.Parsed = Directives{}
+	}
+	return c, nil
+}
+
+//nolint:gocognit,funlen
+func (d *BinaryDecoder) directives() (Directives, error) {
+	n, err := d.len()
+	if err != nil {
+		return nil, err
+	}
+	var block Directives
+	for i := 0; i < n; i++ {
+		stmt := &Directive{}
+		if stmt.Directive, err = d.intern(); err != nil {
+			return nil, err
+		}
+		delta, err := binary.ReadVarint(d.r)
+		if err != nil {
+			return nil, err
+		}
+		d.line += int(delta)
+		stmt.Line = d.line
+		flags, err := d.uint()
+		if err != nil {
+			return nil, err
+		}
+
+		nargs, err := d.len()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Args = []string{}
+		for j := 0; j < nargs; j++ {
+			arg, err := d.string()
+			if err != nil {
+				return nil, err
+			}
+			stmt.Args = append(stmt.Args, arg)
+		}
+
+		if flags&binaryFlagFile != 0 {
+			if stmt.File, err = d.intern(); err != nil {
+				return nil, err
+			}
+		}
+		if flags&binaryFlagComment != 0 {
+			comment, err := d.string()
+			if err != nil {
+				return nil, err
+			}
+			stmt.Comment = &comment
+		}
+		if flags&binaryFlagIncludes != 0 {
+			nincludes, err := d.len()
+			if err != nil {
+				return nil, err
+			}
+			stmt.Includes = []int{}
+			for j := 0; j < nincludes; j++ {
+				inc, err := d.len()
+				if err != nil {
+					return nil, err
+				}
+				stmt.Includes = append(stmt.Includes, inc)
+			}
+		}
+		if err := d.comments(stmt, flags); err != nil {
+			return nil, err
+		}
+		if flags&binaryFlagBlock != 0 {
+			if stmt.Block, err = d.directives(); err != nil {
+				return nil, err
+			}
+			if stmt.Block == nil {
+				stmt.Block = Directives{}
+			}
+		}
+		block = append(block, stmt)
+	}
+	return block, nil
+}
+
+// comments reads the comments attached to a directive and its formatting.
+func (d *BinaryDecoder) comments(stmt *Directive, flags uint64) error {
+	if flags&binaryFlagLeadingComments != 0 {
+		n, err := d.len()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			c, err := d.string()
+			if err != nil {
+				return err
+			}
+			stmt.LeadingComments = append(stmt.LeadingComments, c)
+		}
+	}
+	if flags&binaryFlagTrailingComment != 0 {
+		c, err := d.string()
+		if err != nil {
+			return err
+		}
+		stmt.TrailingComment = &c
+	}
+	if flags&binaryFlagArgComments != 0 {
+		n, err := d.len()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			var c ArgComment
+			if c.Index, err = d.len(); err != nil {
+				return err
+			}
+			if c.Comment, err = d.string(); err != nil {
+				return err
+			}
+			stmt.ArgComments = append(stmt.ArgComments, c)
+		}
+	}
+	if flags&binaryFlagFormatting != 0 {
+		var err error
+		if stmt.BlankLinesBefore, err = d.len(); err != nil {
+			return err
+		}
+		n, err := d.len()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			b, err := d.len()
+			if err != nil {
+				return err
+			}
+			stmt.ArgBreaks = append(stmt.ArgBreaks, b)
+		}
+	}
+	if flags&binaryFlagCommentBlankLines != 0 {
+		n, err := d.len()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			b, err := d.len()
+			if err != nil {
+				return err
+			}
+			stmt.LeadingCommentBlankLines = append(stmt.LeadingCommentBlankLines, b)
+		}
+	}
+	return nil
+}
+
+func (d *BinaryDecoder) uint() (uint64, error) {
+	return binary.ReadUvarint(d.r)
+}
+
+// len reads a length or index and checks that it is within bounds.
+func (d *BinaryDecoder) len() (int, error) {
+	v, err := d.uint()
+	if err != nil {
+		return 0, err
+	}
+	if v > binaryMaxLen {
+		return 0, fmt.Errorf("%w: length %d is too large", ErrInvalidBinary, v)
+	}
+	return int(v), nil
+}
+
+func (d *BinaryDecoder) optionalInt() (*int, error) {
+	v, err := d.len()
+	if err != nil || v == 0 {
+		return nil, err
+	}
+	v--
+	return &v, nil
+}
+
+func (d *BinaryDecoder) string() (string, error) {
+	n, err := d.len()
+	if err != nil {
+		return "", err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (d *BinaryDecoder) intern() (string, error) {
+	i, err := d.len()
+	if err != nil {
+		return "", err
+	}
+	if i == 0 {
+		s, err := d.string()
+		if err != nil {
+			return "", err
+		}
+		d.strings = append(d.strings, s)
+		return s, nil
+	}
+	if i > len(d.strings) {
+		return "", fmt.Errorf("%w: unknown string %d", ErrInvalidBinary, i-1)
+	}
+	return d.strings[i-1], nil
+}
+
+// EncodeBinary writes a payload to w in the binary encoding.
+func EncodeBinary(w io.Writer, payload *Payload) error {
+	return NewBinaryEncoder(w).Encode(payload)
+}
+
+// DecodeBinary reads a payload in the binary encoding from r. Like DecodeYAML, it checks
+// the directives with the given options.
+func DecodeBinary(r io.Reader, options *ParseOptions) (*Payload, error) {
+	payload, err := NewBinaryDecoder(r).Decode()
+	if err != nil {
+		return nil, err
+	}
+	if options == nil {
+		options = &ParseOptions{}
+	}
+	if err := Validate(payload, options); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
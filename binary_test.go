@@ -0,0 +1,186 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, fixture := range []struct {
+		name    string
+		options ParseOptions
+	}{
+		{"simple", ParseOptions{}},
+		{"with-comments", ParseOptions{ParseComments: true}},
+		{"includes-globbed", ParseOptions{}},
+		{"includes-regular", ParseOptions{}},
+		{"if-check", ParseOptions{}},
+		{"types", ParseOptions{}},
+		{"empty-config", ParseOptions{}},
+		{"empty-braces", ParseOptions{}},
+		{"russian-text", ParseOptions{}},
+		{"invalid-map", ParseOptions{}},
+		{"spelling-mistake", ParseOptions{ParseComments: true}},
+		{"messy", ParseOptions{ParseComments: true}},
+		{"attached-comments", ParseOptions{AttachComments: true}},
+		{"formatting", ParseOptions{KeepFormatting: true}},
+		{"formatting", ParseOptions{AttachComments: true, KeepFormatting: true}},
+		{"comments-between-args", ParseOptions{AttachComments: true, KeepFormatting: true}},
+	} {
+		fixture := fixture
+		t.Run(fixture.name, func(t *testing.T) {
+			t.Parallel()
+			payload, err := Parse(getTestConfigPath(fixture.name, "nginx.conf"), &fixture.options)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, EncodeBinary(&buf, payload))
+			decoded, err := NewBinaryDecoder(&buf).Decode()
+			require.NoError(t, err)
+			require.True(t, equalPayloads(t, *payload, *decoded))
+		})
+	}
+}
+
+func TestBinaryLargeConfig(t *testing.T) {
+	t.Parallel()
+	const file = "nginx.conf"
+	options := &ParseOptions{
+		SingleFile: true,
+		Open: func(path string) (io.ReadCloser, error) {
+			f, err := os.Open(getTestConfigPath("large-config", "nginx.conf.bz2"))
+			if err != nil {
+				return nil, err
+			}
+			return struct {
+				io.Reader
+				io.Closer
+			}{bzip2.NewReader(f), f}, nil
+		},
+	}
+	payload, err := Parse(file, options)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, EncodeBinary(&buf, payload))
+	js, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.Less(t, buf.Len(), len(js)/2)
+
+	decoded, err := DecodeBinary(&buf, options)
+	require.NoError(t, err)
+	require.True(t, equalPayloads(t, *payload, *decoded))
+}
+
+func TestBinaryStream(t *testing.T) {
+	t.Parallel()
+	var payloads []*Payload
+	for _, name := range []string{"simple", "includes-regular", "simple"} {
+		payload, err := Parse(getTestConfigPath(name, "nginx.conf"), &ParseOptions{})
+		require.NoError(t, err)
+		payloads = append(payloads, payload)
+	}
+
+	var buf bytes.Buffer
+	enc := NewBinaryEncoder(&buf)
+	var sizes []int
+	for _, payload := range payloads {
+		before := buf.Len()
+		require.NoError(t, enc.Encode(payload))
+		sizes = append(sizes, buf.Len()-before)
+	}
+	// the strings of the first payload are already in the table
+	require.Less(t, sizes[2], sizes[0])
+
+	dec := NewBinaryDecoder(&buf)
+	for _, payload := range payloads {
+		decoded, err := dec.Decode()
+		require.NoError(t, err)
+		require.True(t, equalPayloads(t, *payload, *decoded))
+	}
+	_, err := dec.Decode()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestBinaryDecodeInvalid(t *testing.T) {
+	t.Parallel()
+	payload, err := Parse(getTestConfigPath("simple", "nginx.conf"), &ParseOptions{})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, EncodeBinary(&buf, payload))
+	encoded := buf.Bytes()
+
+	_, err = NewBinaryDecoder(bytes.NewReader([]byte(`{"status":"ok"}`))).Decode()
+	require.ErrorIs(t, err, ErrInvalidBinary)
+
+	_, err = NewBinaryDecoder(bytes.NewReader(encoded[:len(encoded)/2])).Decode()
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestDecodeBinaryValidates(t *testing.T) {
+	t.Parallel()
+	payload := &Payload{
+		Status: "ok",
+		Errors: []PayloadError{},
+		Config: []Config{{
+			File:   "nginx.conf",
+			Status: "ok",
+			Errors: []ConfigError{},
+			Parsed: Directives{{Directive: "listen", Line: 1, Args: []string{"80"}}},
+		}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, EncodeBinary(&buf, payload))
+
+	_, err := DecodeBinary(&buf, &ParseOptions{StopParsingOnError: true})
+	require.EqualError(t, err, `"listen" directive is not allowed here in nginx.conf:1`)
+}
+
+func TestDecodeBinaryKeepsErrors(t *testing.T) {
+	t.Parallel()
+	payload := &Payload{
+		Status: "ok",
+		Errors: []PayloadError{},
+		Config: []Config{{
+			File:   "nginx.conf",
+			Status: "ok",
+			Errors: []ConfigError{},
+			Parsed: Directives{
+				{Directive: "listen", Line: 1, Args: []string{"80"}},
+				{Directive: "events", Line: 2, Args: []string{}, Block: Directives{}},
+			},
+		}},
+	}
+	require.NoError(t, Validate(payload, &ParseOptions{}))
+	require.Len(t, payload.Errors, 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, EncodeBinary(&buf, payload))
+	encoded := buf.Bytes()
+
+	// the errors found again by Validate aren't added twice
+	decoded, err := DecodeBinary(bytes.NewReader(encoded), nil)
+	require.NoError(t, err)
+	require.Len(t, decoded.Errors, 1)
+	require.Len(t, decoded.Config[0].Errors, 1)
+	require.EqualError(t, decoded.Errors[0].Error, `"listen" directive is not allowed here in nginx.conf:1`)
+	require.True(t, equalPayloads(t, *payload, *decoded))
+
+	// nor returned as new ones
+	_, err = DecodeBinary(bytes.NewReader(encoded), &ParseOptions{StopParsingOnError: true})
+	require.NoError(t, err)
+}
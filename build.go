@@ -18,9 +18,31 @@ import (
 )
 
 type BuildOptions struct {
-	Indent      int
-	Tabs        bool
-	Header      bool
+	Indent int
+	Tabs   bool
+	Header bool
+
+	// If true, the blank lines recorded in the BlankLinesBefore and LeadingCommentBlankLines
+	// of each directive are kept.
+	KeepBlankLines bool
+
+	// If true, the arguments recorded in the ArgBreaks of each directive start on a new
+	// line, indented one level deeper than the directive.
+	KeepArgBreaks bool
+
+	// If greater than zero, an argument that would make its line longer than MaxWidth
+	// starts on a new line, indented one level deeper than the directive. The first
+	// argument always stays on the line of the directive.
+	MaxWidth int
+
+	// If true, the arguments of consecutive directives without blocks are aligned in a
+	// column. A block, a comment on its own line or a kept blank line ends the group.
+	AlignValues bool
+
+	// AlignBlocks holds the names of block directives, like "map", whose bodies are
+	// aligned as with AlignValues.
+	AlignBlocks []string
+
 	Builders    []RegisterBuilder // handle specific directives
 	extBuilders map[string]Builder
 }
@@ -155,8 +177,13 @@ func Build(w io.Writer, config Config, options *BuildOptions) error {
 	return err
 }
 
-//nolint:gocognit
+//nolint:gocognit,gocyclo
 func buildBlock(sb io.StringWriter, parent *Directive, block Directives, depth int, lastLine int, options *BuildOptions) {
+	var widths []int
+	if options.AlignValues || (parent != nil && contains(options.AlignBlocks, parent.Directive)) {
+		widths = alignedWidths(block, options)
+	}
+
 	for i, stmt := range block {
 		directive := Enquote(stmt.Directive)
 		// if the this statement is a comment on the same line as the preview, do not emit EOL for this stmt
@@ -171,6 +198,20 @@ func buildBlock(sb io.StringWriter, parent *Directive, block Directives, depth i
 			_, _ = sb.WriteString("\n")
 		}
 
+		if options.KeepBlankLines && (i != 0 || parent != nil) {
+			_, _ = sb.WriteString(strings.Repeat("\n", stmt.BlankLinesBefore))
+		}
+
+		for j, comment := range stmt.LeadingComments {
+			_, _ = sb.WriteString(margin(options, depth))
+			_, _ = sb.WriteString("#")
+			_, _ = sb.WriteString(comment)
+			_, _ = sb.WriteString("\n")
+			if options.KeepBlankLines && j < len(stmt.LeadingCommentBlankLines) {
+				_, _ = sb.WriteString(strings.Repeat("\n", stmt.LeadingCommentBlankLines[j]))
+			}
+		}
+
 		_, _ = sb.WriteString(margin(options, depth))
 
 		if options.extBuilders != nil {
@@ -185,28 +226,19 @@ func buildBlock(sb io.StringWriter, parent *Directive, block Directives, depth i
 			_, _ = sb.WriteString(*stmt.Comment)
 		} else {
 			_, _ = sb.WriteString(directive)
-
-			// special handling for if statements
-			if directive == "if" {
-				_, _ = sb.WriteString(" (")
-				for i, arg := range stmt.Args {
-					if i > 0 {
-						_, _ = sb.WriteString(" ")
-					}
-					_, _ = sb.WriteString(Enquote(arg))
-				}
-				_, _ = sb.WriteString(")")
-			} else {
-				for _, arg := range stmt.Args {
-					_, _ = sb.WriteString(" ")
-					_, _ = sb.WriteString(Enquote(arg))
-				}
+			col := marginWidth(options, depth) + utf8.RuneCountInString(directive)
+			if widths != nil && widths[i] > 0 {
+				_, _ = sb.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(directive)))
+				col = marginWidth(options, depth) + widths[i]
 			}
+			buildArgs(sb, stmt, col, depth, options)
 
 			if !stmt.IsBlock() {
 				_, _ = sb.WriteString(";")
+				buildTrailingComment(sb, stmt)
 			} else {
 				_, _ = sb.WriteString(" {")
+				buildTrailingComment(sb, stmt)
 				stmt := stmt
 				buildBlock(sb, stmt, stmt.Block, depth+1, stmt.Line, options)
 				_, _ = sb.WriteString("\n")
@@ -219,6 +251,125 @@ func buildBlock(sb io.StringWriter, parent *Directive, block Directives, depth i
 	}
 }
 
+// buildArgs writes the arguments of a directive that starts its line at col, with the
+// comments found between them. A comment runs to the end of the line, so the arguments
+// after it continue on the next line, indented one level deeper than the directive, like
+// those wrapped because of KeepArgBreaks or MaxWidth.
+//
+//nolint:gocognit
+func buildArgs(sb io.StringWriter, stmt *Directive, col int, depth int, options *BuildOptions) {
+	// special handling for if statements
+	isIf := Enquote(stmt.Directive) == "if"
+	lineStart := false
+	if isIf {
+		_, _ = sb.WriteString(" (")
+		col += 2
+		lineStart = true
+	}
+
+	newLine := func() {
+		_, _ = sb.WriteString("\n")
+		_, _ = sb.WriteString(margin(options, depth+1))
+		col = marginWidth(options, depth+1)
+		lineStart = true
+	}
+
+	writeComments := func(index int) {
+		for _, c := range stmt.ArgComments {
+			if c.Index == index {
+				_, _ = sb.WriteString(" #")
+				_, _ = sb.WriteString(c.Comment)
+				newLine()
+			}
+		}
+	}
+
+	for i, arg := range stmt.Args {
+		writeComments(i)
+		arg = Enquote(arg)
+		width := utf8.RuneCountInString(arg)
+		if !lineStart {
+			if (options.KeepArgBreaks && containsInt(stmt.ArgBreaks, i)) ||
+				(options.MaxWidth > 0 && i > 0 && col+1+width > options.MaxWidth) {
+				newLine()
+			}
+		}
+		if !lineStart {
+			_, _ = sb.WriteString(" ")
+			col++
+		}
+		lineStart = false
+		_, _ = sb.WriteString(arg)
+		col += width
+	}
+	writeComments(len(stmt.Args))
+
+	if isIf {
+		_, _ = sb.WriteString(")")
+	}
+}
+
+func buildTrailingComment(sb io.StringWriter, stmt *Directive) {
+	if stmt.TrailingComment != nil {
+		_, _ = sb.WriteString(" #")
+		_, _ = sb.WriteString(*stmt.TrailingComment)
+	}
+}
+
+// alignedWidths returns the width that the name of each directive in a block is padded to
+// so that its arguments line up with those of the directives around it, or 0 if the
+// directive isn't aligned.
+func alignedWidths(block Directives, options *BuildOptions) []int {
+	widths := make([]int, len(block))
+	var group []int
+	flush := func() {
+		if len(group) > 1 {
+			width := 0
+			for _, i := range group {
+				if w := utf8.RuneCountInString(Enquote(block[i].Directive)); w > width {
+					width = w
+				}
+			}
+			for _, i := range group {
+				widths[i] = width
+			}
+		}
+		group = group[:0]
+	}
+
+	for i, stmt := range block {
+		// comments on the line of the directive before them don't interrupt the group
+		if stmt.IsComment() && i > 0 && block[i-1].Line == stmt.Line {
+			continue
+		}
+		_, custom := options.extBuilders[Enquote(stmt.Directive)]
+		if stmt.IsBlock() || stmt.IsComment() || custom || len(stmt.Args) == 0 || stmt.Directive == "if" {
+			flush()
+			continue
+		}
+		if len(stmt.LeadingComments) > 0 || (options.KeepBlankLines && stmt.BlankLinesBefore > 0) {
+			flush()
+		}
+		group = append(group, i)
+	}
+	flush()
+	return widths
+}
+
+func containsInt(s []int, v int) bool {
+	for _, i := range s {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// marginWidth returns the number of columns taken by the margin, counting a tab as Indent columns.
+func marginWidth(options *BuildOptions, depth int) int {
+	return depth * options.Indent
+}
+
 func margin(options *BuildOptions, depth int) string {
 	indent := depth * options.Indent
 	if indent < MaxIndent {
@@ -332,6 +332,83 @@ var buildFixtures = []buildFixture{
 			"\n            return a + b;          -- return the $sum value normally" +
 			"\n        }",
 	},
+	{
+		name:    "attached-comments",
+		options: BuildOptions{},
+		parsed: Directives{
+			{
+				Directive:       "user",
+				Args:            []string{"nginx"},
+				LeadingComments: []string{" main", " settings"},
+				TrailingComment: pStr(" unprivileged"),
+			},
+			{
+				Directive:       "server",
+				Args:            []string{},
+				TrailingComment: pStr(" server"),
+				Block: Directives{
+					{
+						Directive:   "server_name",
+						Args:        []string{"example.com", "www.example.com"},
+						ArgComments: []ArgComment{{Index: 1, Comment: " primary"}, {Index: 2, Comment: " www"}},
+					},
+					{
+						Directive:   "if",
+						Args:        []string{"$host", "=", "example.com"},
+						ArgComments: []ArgComment{{Index: 0, Comment: " host"}},
+						Block:       Directives{},
+					},
+				},
+			},
+		},
+		expected: "# main\n# settings\nuser nginx; # unprivileged\nserver { # server\n" +
+			"    server_name example.com # primary\n        www.example.com # www\n        ;\n" +
+			"    if ( # host\n        $host = example.com) {\n    }\n}",
+	},
+	{
+		name:    "align-values",
+		options: BuildOptions{AlignValues: true},
+		parsed: Directives{
+			{Directive: "user", Line: 1, Args: []string{"nginx"}},
+			{Directive: "worker_processes", Line: 2, Args: []string{"auto"}},
+			{Directive: "#", Line: 2, Args: []string{}, Comment: pStr(" inline")},
+			{Directive: "pid", Line: 3, Args: []string{"/run/nginx.pid"}},
+			{Directive: "events", Line: 4, Args: []string{}, Block: Directives{}},
+			{Directive: "error_log", Line: 5, Args: []string{"stderr"}},
+			{Directive: "daemon", Line: 6, Args: []string{"off"}, LeadingComments: []string{" group"}},
+			{Directive: "worker_rlimit_nofile", Line: 7, Args: []string{"1024"}},
+		},
+		expected: "user             nginx;\nworker_processes auto; # inline\npid              /run/nginx.pid;\n" +
+			"events {\n}\nerror_log stderr;\n# group\ndaemon               off;\nworker_rlimit_nofile 1024;",
+	},
+	{
+		name:    "max-width",
+		options: BuildOptions{MaxWidth: 30},
+		parsed: Directives{
+			{
+				Directive: "http",
+				Args:      []string{},
+				Block: Directives{
+					{Directive: "server_name", Args: []string{"example.com", "www.example.com", "example.org"}},
+					{Directive: "index", Args: []string{"a-very-long-index-file-name.html"}},
+				},
+			},
+		},
+		expected: "http {\n    server_name example.com\n        www.example.com\n        example.org;\n" +
+			"    index a-very-long-index-file-name.html;\n}",
+	},
+	{
+		name:    "keep-blank-lines-and-arg-breaks",
+		options: BuildOptions{KeepBlankLines: true, KeepArgBreaks: true},
+		parsed: Directives{
+			{Directive: "user", Args: []string{"nginx"}, BlankLinesBefore: 1},
+			{Directive: "events", Args: []string{}, BlankLinesBefore: 2, Block: Directives{
+				{Directive: "worker_connections", Args: []string{"1024"}, BlankLinesBefore: 1},
+			}},
+			{Directive: "server_name", Args: []string{"a", "b", "c"}, ArgBreaks: []int{1, 2}},
+		},
+		expected: "user nginx;\n\n\nevents {\n\n    worker_connections 1024;\n}\nserver_name a\n    b\n    c;",
+	},
 }
 
 func TestBuild(t *testing.T) {
@@ -434,6 +511,7 @@ var compareFixtures = []compareFixture{
 	{"quoted-right-brace", ParseOptions{}},
 	{"directive-with-space", ParseOptions{ErrorOnUnknownDirectives: true}},
 	{"empty-config", ParseOptions{}},
+	{"attached-comments", ParseOptions{AttachComments: true}},
 }
 
 //nolint:gocognit
@@ -588,6 +666,18 @@ func equalIncludes(b1, b2 []int) bool {
 	return true
 }
 
+func equalArgComments(c1, c2 []ArgComment) bool {
+	if len(c1) != len(c2) {
+		return false
+	}
+	for i := range c1 {
+		if c1[i] != c2[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func equalDirectives(d1, d2 *Directive) bool {
 	if d1.Directive != d2.Directive ||
 		d1.File != d2.File ||
@@ -595,13 +685,92 @@ func equalDirectives(d1, d2 *Directive) bool {
 		!equalIncludes(d1.Includes, d2.Includes) ||
 		!equalBlocks(d1.Block, d2.Block) ||
 		(d1.Comment == nil) != (d2.Comment == nil) ||
-		(d1.Comment != nil && *d1.Comment != *d2.Comment) {
+		(d1.Comment != nil && *d1.Comment != *d2.Comment) ||
+		!equals(d1.LeadingComments, d2.LeadingComments) ||
+		!strPtrEqual(d1.TrailingComment, d2.TrailingComment) ||
+		!equalArgComments(d1.ArgComments, d2.ArgComments) ||
+		d1.BlankLinesBefore != d2.BlankLinesBefore ||
+		!equalIncludes(d1.ArgBreaks, d2.ArgBreaks) {
 		return false
 	}
 
 	return true
 }
 
+func TestBuildKeepFormatting(t *testing.T) {
+	t.Parallel()
+	payload, err := Parse(getTestConfigPath("formatting", "nginx.conf"), &ParseOptions{KeepFormatting: true, ParseComments: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	options := &BuildOptions{KeepBlankLines: true, KeepArgBreaks: true}
+	if err := Build(&buf, payload.Config[0], options); err != nil {
+		t.Fatal(err)
+	}
+	expected := `user nginx;
+worker_processes auto;
+
+
+events {
+    worker_connections 1024;
+}
+
+http {
+
+    log_format main '$remote_addr - $remote_user [$time_local] "$request" '
+        '$status $body_bytes_sent "$http_referer" '
+        '"$http_user_agent" "$http_x_forwarded_for"';
+
+    sendfile on;
+    keepalive_timeout 65;
+    # servers
+
+    server {
+        listen 80;
+        server_name example.com
+            www.example.com;
+    }
+}`
+	if got := buf.String(); got != expected {
+		t.Fatalf("expected: %#v\nbut got: %#v", expected, got)
+	}
+
+	// the rebuilt config keeps its formatting when it is parsed again
+	rebuilt := filepath.Join(t.TempDir(), "nginx.conf")
+	if err := os.WriteFile(rebuilt, buf.Bytes(), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	payload2, err := Parse(rebuilt, &ParseOptions{KeepFormatting: true, ParseComments: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, stmt := range payload.Config[0].Parsed {
+		stmt2 := payload2.Config[0].Parsed[i]
+		if !stmt.Equal(stmt2) {
+			t.Fatalf("expected: %#v\nbut got: %#v", stmt, stmt2)
+		}
+	}
+
+	// attached comments keep the blank lines between them and their directive
+	attached, err := Parse(getTestConfigPath("formatting", "nginx.conf"), &ParseOptions{KeepFormatting: true, AttachComments: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := attached.Config[0].Parsed[3].Block[3]
+	if server.BlankLinesBefore != 0 || len(server.LeadingCommentBlankLines) != 1 || server.LeadingCommentBlankLines[0] != 1 {
+		t.Fatalf("expected one blank line after the leading comment, got: %#v", server)
+	}
+	buf.Reset()
+	if err := Build(&buf, attached.Config[0], options); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != expected {
+		t.Fatalf("expected: %#v\nbut got: %#v", expected, got)
+	}
+}
+
 func TestBuildInto(t *testing.T) {
 	t.Parallel()
 	for _, fixture := range buildFilesFixtures {
@@ -31,6 +31,17 @@ func (f *filterFlag) String() string {
 	return fmt.Sprintf("%v", *f)
 }
 
+type defineFlag []string
+
+func (f *defineFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func (f *defineFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
 type overrideItem struct {
 	directive string
 	masks     []generator.Mask
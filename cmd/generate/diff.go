@@ -0,0 +1,185 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package main
+
+import (
+	"archive/tar"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/nginxinc/nginx-go-crossplane/internal/generator"
+)
+
+const diffUsage = `Usage: generate diff [flags] OLD NEW
+
+Compares the directives defined in the source code at OLD and NEW, which are paths, or
+git refs like release-1.25.3 and master of the repository in -git-dir, and reports the
+directives added, removed and with changed masks.
+
+Flags:
+`
+
+// runDiff runs the diff command with the arguments after "diff", writing the diff to w.
+func runDiff(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), diffUsage)
+		fs.PrintDefaults()
+	}
+
+	var (
+		format = fs.String("format", "text", "Format of the diff, text or json.")
+		gitDir = fs.String("git-dir", "", "A git repository. If this is provided, OLD and NEW are git refs of it, "+
+			"like branches or tags, instead of paths. (optional)")
+		srcPath = fs.String("src-path", "", "A path, relative to the root of the git repository, "+
+			"to compare instead of the whole tree, like src/http. Only used with git-dir. (optional)")
+		configPath = fs.String("config-path", "", "The path of json config file. "+
+			"Its defines, filter and override are used for both source trees. (optional)")
+		filterflags       filterFlag
+		defines           defineFlag
+		undefines         defineFlag
+		directiveOverride override
+	)
+	fs.Var(&filterflags, "filter", "A directive to exclude from the diff, like -filter directive1 -filter directive2. "+
+		"If this is provided, the filter in json config will be ignored. (optional)")
+	fs.Var(&defines, "define", "A feature macro, as NAME or NAME=VALUE, like for generate. "+
+		"If this is provided, the defines in json config will be ignored. (optional)")
+	fs.Var(&undefines, "undefine", "A feature macro that isn't defined, like for generate. "+
+		"If this is provided, the undefines in json config will be ignored. (optional)")
+	fs.Var(&directiveOverride, "override", "The masks of a directive in both source trees, like for generate. "+
+		"If this is provided, the override in json config will be ignored. (optional)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return errors.New("diff needs OLD and NEW")
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown diff format %q", *format)
+	}
+
+	var config generator.GenerateConfig
+	if *configPath != "" {
+		var err error
+		if config, err = configFromFile(*configPath); err != nil {
+			return err
+		}
+	}
+	if filterflags.filter != nil {
+		config.Filter = filterflags.filter
+	}
+	if defines != nil {
+		config.Defines = defines
+	}
+	if undefines != nil {
+		config.Undefines = undefines
+	}
+	if directiveOverride != nil {
+		config.Override = directiveOverride
+	}
+
+	oldPath, newPath := fs.Arg(0), fs.Arg(1)
+	if *gitDir != "" {
+		tmpDir, err := os.MkdirTemp("", "generate-diff")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmpDir)
+
+		if oldPath, err = extractGitRef(*gitDir, fs.Arg(0), *srcPath, filepath.Join(tmpDir, "old")); err != nil {
+			return err
+		}
+		if newPath, err = extractGitRef(*gitDir, fs.Arg(1), *srcPath, filepath.Join(tmpDir, "new")); err != nil {
+			return err
+		}
+	}
+
+	d, err := generator.Diff(oldPath, newPath, config)
+	if err != nil {
+		return err
+	}
+	if *format == "json" {
+		return d.WriteJSON(w)
+	}
+	return d.WriteText(w)
+}
+
+// extractGitRef writes the tree of ref in the git repository gitDir, or only its
+// subdirectory srcPath if it isn't empty, into dir and returns the path of the tree.
+func extractGitRef(gitDir string, ref string, srcPath string, dir string) (string, error) {
+	args := []string{"-C", gitDir, "archive", "--format=tar", ref}
+	if srcPath != "" {
+		args = append(args, "--", srcPath)
+	}
+	cmd := exec.Command("git", args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", err
+	}
+	if err = cmd.Start(); err != nil {
+		return "", err
+	}
+
+	extractErr := extractTar(stdout, dir)
+	// read what's left so that git doesn't block on a full pipe
+	_, _ = io.Copy(io.Discard, stdout)
+	if err = cmd.Wait(); err != nil {
+		return "", fmt.Errorf("git archive %s: %w: %s", ref, err, strings.TrimSpace(stderr.String()))
+	}
+	if extractErr != nil {
+		return "", fmt.Errorf("git archive %s: %w", ref, extractErr)
+	}
+	return filepath.Join(dir, filepath.FromSlash(srcPath)), nil
+}
+
+// extractTar writes the regular files of the tar archive r into dir.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid path %q in archive", hdr.Name)
+		}
+		path := filepath.Join(dir, name)
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(f, tr) //nolint:gosec
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
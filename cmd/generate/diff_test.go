@@ -0,0 +1,89 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const diffTestSrc = "../../internal/generator/testdata/source_codes/diff"
+
+func TestRunDiff(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	err := runDiff([]string{"-filter", "my_filtered", filepath.Join(diffTestSrc, "old"), filepath.Join(diffTestSrc, "new")}, &buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "  + my_added ")
+	require.Contains(t, buf.String(), "1 added, 1 removed, 1 changed\n")
+
+	buf.Reset()
+	err = runDiff([]string{"-format", "json", filepath.Join(diffTestSrc, "old"), filepath.Join(diffTestSrc, "new")}, &buf)
+	require.NoError(t, err)
+	var d struct {
+		Added   []struct{ Name string }
+		Removed []struct{ Name string }
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &d))
+	require.Len(t, d.Added, 1)
+	require.Len(t, d.Removed, 2)
+
+	require.EqualError(t, runDiff([]string{"-format", "xml", "a", "b"}, &buf), `unknown diff format "xml"`)
+	require.EqualError(t, runDiff([]string{"a"}, &buf), "diff needs OLD and NEW")
+}
+
+func TestRunDiffGit(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	copySrc := func(dir string) {
+		src, err := os.ReadFile(filepath.Join(diffTestSrc, dir, "ngx_http_my_module.c"))
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(repo, "src"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "src", "ngx_http_my_module.c"), src, 0o644))
+	}
+
+	git("init", "-q")
+	copySrc("old")
+	git("add", "-A")
+	git("commit", "-qm", "old")
+	git("tag", "old")
+	copySrc("new")
+	git("commit", "-qam", "new")
+
+	var buf bytes.Buffer
+	err := runDiff([]string{"-git-dir", repo, "-src-path", "src", "old", "HEAD"}, &buf)
+	require.NoError(t, err)
+	require.Equal(t, "added:\n"+
+		"  + my_added ngxHTTPMainConf|ngxHTTPSrvConf|ngxHTTPLocConf|ngxConfTake1|ngxConfUnique\n"+
+		"removed:\n"+
+		"  - my_filtered ngxHTTPLocConf|ngxConfNoArgs\n"+
+		"  - my_removed ngxHTTPLocConf|ngxConfTake12\n"+
+		"changed:\n"+
+		"  ~ my_changed\n"+
+		"      - ngxHTTPMainConf|ngxConfTake1\n"+
+		"      + ngxHTTPMainConf|ngxConfTake12\n"+
+		"1 added, 2 removed, 1 changed\n", buf.String())
+
+	err = runDiff([]string{"-git-dir", repo, "missing", "HEAD"}, &buf)
+	require.ErrorContains(t, err, "git archive missing: ")
+}
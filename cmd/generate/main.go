@@ -9,7 +9,9 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
+	"io"
 	"log"
 	"os"
 
@@ -32,14 +34,33 @@ func configFromFile(path string) (generator.GenerateConfig, error) {
 	return config, nil
 }
 
-//nolint:funlen
+func readNginxV(path string) (string, error) {
+	if path == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		return string(b), err
+	}
+	b, err := os.ReadFile(path)
+	return string(b), err
+}
+
+//nolint:funlen,gocognit
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := runDiff(os.Args[2:], os.Stdout); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return
+			}
+			log.Fatal(err)
+		}
+		return
+	}
+
 	var (
 		sourceCodePath = flag.String("src-path", "",
 			"The path of source code your want to generate support from, it can be either a file or a directory. (required)")
 		configPath = flag.String("config-path", "", "The path of json config file.\n"+
-			"The file can contain directiveMapName, matchFuncName, matchFuncComment, filter, and override.\n"+
-			"They provide same functions as other arguments directive-map-name, match-func-name, match-func-comment, filter, and override.\n"+
+			"The file can contain directiveMapName, matchFuncName, matchFuncComment, registryFuncName, argTypesMapName, module, outputFormat, defines, undefines, nginxV, modulesDir, filter, and override.\n"+
+			"They provide same functions as other arguments directive-map-name, match-func-name, match-func-comment, registry-func-name, arg-types-map-name, module, output-format, define, undefine, nginx-v, modules-dir, filter, and override.\n"+
 			"It will unmarsh to generator.GenerateConfig. (optional)")
 		directiveMapName = flag.String("directive-map-name", "", "Name of the generated map variable."+
 			"Normally it should start with lowercase to avoid export. If this is provided, the directiveMapName in json config will be ignored.\n"+
@@ -50,13 +71,42 @@ func main() {
 		matchFnComment = flag.String("match-func-comment", "", "The code comment for generated matchFunc."+
 			"You can add some explanations like which modules included in it. Normally it should start with match-func-name.\n"+
 			"If this is provided, the matchFuncComment in json config will be ignored. (optional)")
+		registryFuncName = flag.String("registry-func-name", "", "Name of the generated function returning a Registry of the directives."+
+			"If this is provided, the registryFuncName in json config will be ignored. (optional)")
+		argTypesMapName = flag.String("arg-types-map-name", "", "Name of the generated map variable with the ArgType of the directives, "+
+			"as found in the set function, offset and post handler of their ngx_command_t, for validating their values. "+
+			"If this is provided, the argTypesMapName in json config will be ignored. (optional)")
+		module = flag.String("module", "", "Module name set in the specs of the generated Registry."+
+			"If this is provided, the module in json config will be ignored. (optional)")
+		outputFormat = flag.String("output-format", "", "Format of the output, go or json. json writes a directive spec file that "+
+			"crossplane.LoadRegistry reads at runtime, and doesn't need directive-map-name or match-func-name."+
+			"If this is provided, the outputFormat in json config will be ignored. (optional, default go)")
+		nginxVPath = flag.String("nginx-v", "", "The path of a file with the output of nginx -V, or - to read it from stdin, "+
+			"like: nginx -V 2>&1 | go run ./cmd/generate -nginx-v - ...\n"+
+			"src-path is then the nginx source tree, and only the directives of the modules nginx was built with are generated, "+
+			"along with those of the modules added with --add-module. "+
+			"If this is provided, the nginxV in json config will be ignored. (optional)")
+		modulesDir = flag.String("modules-dir", "", "The directory in which the modules added with --add-module are looked up "+
+			"by the name of their directory, when they are not found at the path in the nginx -V output. "+
+			"If this is provided, the modulesDir in json config will be ignored. (optional)")
 		filterflags       filterFlag
+		defines           defineFlag
+		undefines         defineFlag
 		directiveOverride override
 	)
 	flag.Var(&filterflags, "filter",
 		"A list of strings specifying the directives to exclude from the output. "+
 			"An example is: -filter directive1 -filter directive2...\n"+
 			"If this is provided, the filter in json config will be igonored. (optional)")
+	flag.Var(&defines, "define",
+		"A feature macro nginx was configured with, as NAME or NAME=VALUE, like -define NGX_HTTP_SSL -define NGX_HTTP_V3.\n"+
+			"Directives excluded by #if, #ifdef and #ifndef with these macros are not generated. "+
+			"Conditionals with macros that are neither defined nor undefined keep their directives. "+
+			"Without any, conditionals are ignored and all directives are generated.\n"+
+			"If this is provided, the defines in json config will be ignored. (optional)")
+	flag.Var(&undefines, "undefine",
+		"A feature macro nginx was not configured with, like -undefine NGX_HTTP_V3.\n"+
+			"If this is provided, the undefines in json config will be ignored. (optional)")
 	flag.Var(&directiveOverride, "override",
 		"A list of strings, used to override the output. "+
 			"It should follow the format:{directive:bitmask00|bitmask01...,bitmask10|bitmask11...}"+"\n"+
@@ -84,10 +134,45 @@ func main() {
 		config.MatchFuncName = *matchFuncName
 	}
 
+	if *registryFuncName != "" {
+		config.RegistryFuncName = *registryFuncName
+	}
+
+	if *argTypesMapName != "" {
+		config.ArgTypesMapName = *argTypesMapName
+	}
+
+	if *module != "" {
+		config.Module = *module
+	}
+
+	if *outputFormat != "" {
+		config.OutputFormat = *outputFormat
+	}
+
 	if filterflags.filter != nil {
 		config.Filter = filterflags.filter
 	}
 
+	if defines != nil {
+		config.Defines = defines
+	}
+
+	if undefines != nil {
+		config.Undefines = undefines
+	}
+
+	if *nginxVPath != "" {
+		config.NginxV, err = readNginxV(*nginxVPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *modulesDir != "" {
+		config.ModulesDir = *modulesDir
+	}
+
 	if directiveOverride != nil {
 		config.Override = directiveOverride
 	}
@@ -100,12 +185,14 @@ func main() {
 		log.Fatal("src-path can't be empty")
 	}
 
-	if config.DirectiveMapName == "" {
-		log.Fatal("directiveMapName can't be empty")
-	}
+	if config.OutputFormat != generator.OutputJSON {
+		if config.DirectiveMapName == "" {
+			log.Fatal("directiveMapName can't be empty")
+		}
 
-	if config.MatchFuncName == "" {
-		log.Fatal("matchFuncName can't be empty")
+		if config.MatchFuncName == "" {
+			log.Fatal("matchFuncName can't be empty")
+		}
 	}
 
 	err = generator.Generate(*sourceCodePath, os.Stdout, config)
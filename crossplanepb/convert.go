@@ -0,0 +1,206 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+// Package crossplanepb holds the protobuf messages for crossplane payloads, generated
+// from crossplane.proto, and the functions that convert them to and from the Go types.
+package crossplanepb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative crossplane.proto
+
+import (
+	"errors"
+
+	crossplane "github.com/nginxinc/nginx-go-crossplane"
+)
+
+func int32Ptr(i *int) *int32 {
+	if i == nil {
+		return nil
+	}
+	v := int32(*i)
+	return &v
+}
+
+func intPtr(i *int32) *int {
+	if i == nil {
+		return nil
+	}
+	v := int(*i)
+	return &v
+}
+
+// FromPayload converts a crossplane payload to its protobuf message.
+func FromPayload(p *crossplane.Payload) *Payload {
+	msg := &Payload{
+		Status: p.Status,
+		Errors: make([]*PayloadError, 0, len(p.Errors)),
+		Config: make([]*Config, 0, len(p.Config)),
+	}
+	for _, e := range p.Errors {
+		msg.Errors = append(msg.Errors, &PayloadError{File: e.File, Line: int32Ptr(e.Line), Error: FromError(e.Error)})
+	}
+	for i := range p.Config {
+		msg.Config = append(msg.Config, FromConfig(&p.Config[i]))
+	}
+	return msg
+}
+
+// ToPayload converts a protobuf message back to a crossplane payload.
+func ToPayload(msg *Payload) *crossplane.Payload {
+	p := &crossplane.Payload{
+		Status: msg.GetStatus(),
+		Errors: make([]crossplane.PayloadError, 0, len(msg.GetErrors())),
+		Config: make([]crossplane.Config, 0, len(msg.GetConfig())),
+	}
+	for _, e := range msg.GetErrors() {
+		p.Errors = append(p.Errors, crossplane.PayloadError{File: e.GetFile(), Line: intPtr(e.Line), Error: ToError(e.GetError())})
+	}
+	for _, c := range msg.GetConfig() {
+		p.Config = append(p.Config, *ToConfig(c))
+	}
+	return p
+}
+
+// FromConfig converts a crossplane config to its protobuf message.
+func FromConfig(c *crossplane.Config) *Config {
+	msg := &Config{
+		File:   c.File,
+		Status: c.Status,
+		Errors: make([]*ConfigError, 0, len(c.Errors)),
+		Parsed: FromDirectives(c.Parsed),
+	}
+	for _, e := range c.Errors {
+		msg.Errors = append(msg.Errors, &ConfigError{Line: int32Ptr(e.Line), Error: FromError(e.Error)})
+	}
+	return msg
+}
+
+// ToConfig converts a protobuf message back to a crossplane config.
+func ToConfig(msg *Config) *crossplane.Config {
+	c := &crossplane.Config{
+		File:   msg.GetFile(),
+		Status: msg.GetStatus(),
+		Errors: make([]crossplane.ConfigError, 0, len(msg.GetErrors())),
+		Parsed: ToDirectives(msg.GetParsed()),
+	}
+	for _, e := range msg.GetErrors() {
+		c.Errors = append(c.Errors, crossplane.ConfigError{Line: intPtr(e.Line), Error: ToError(e.GetError())})
+	}
+	return c
+}
+
+// FromDirectives converts crossplane directives to their protobuf messages.
+func FromDirectives(d crossplane.Directives) []*Directive {
+	msgs := make([]*Directive, 0, len(d))
+	for _, stmt := range d {
+		msg := &Directive{
+			Directive: stmt.Directive,
+			Line:      int32(stmt.Line),
+			Args:      stmt.Args,
+			File:      stmt.File,
+			Block:     FromDirectives(stmt.Block),
+			IsBlock:   stmt.IsBlock(),
+			Comment:   stmt.Comment,
+
+			LeadingComments: stmt.LeadingComments,
+			TrailingComment: stmt.TrailingComment,
+
+			BlankLinesBefore: int32(stmt.BlankLinesBefore),
+		}
+		for _, i := range stmt.Includes {
+			msg.Includes = append(msg.Includes, int32(i))
+		}
+		for _, b := range stmt.ArgBreaks {
+			msg.ArgBreaks = append(msg.ArgBreaks, int32(b))
+		}
+		for _, b := range stmt.LeadingCommentBlankLines {
+			msg.LeadingCommentBlankLines = append(msg.LeadingCommentBlankLines, int32(b))
+		}
+		for _, c := range stmt.ArgComments {
+			msg.ArgComments = append(msg.ArgComments, &ArgComment{Index: int32(c.Index), Comment: c.Comment})
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// ToDirectives converts protobuf messages back to crossplane directives. Like Parse, it
+// returns nil if there are no directives.
+func ToDirectives(msgs []*Directive) crossplane.Directives {
+	var d crossplane.Directives
+	for _, msg := range msgs {
+		stmt := &crossplane.Directive{
+			Directive: msg.GetDirective(),
+			Line:      int(msg.GetLine()),
+			Args:      msg.GetArgs(),
+			File:      msg.GetFile(),
+			Comment:   msg.Comment,
+
+			LeadingComments: msg.GetLeadingComments(),
+			TrailingComment: msg.TrailingComment,
+
+			BlankLinesBefore: int(msg.GetBlankLinesBefore()),
+		}
+		if stmt.Args == nil {
+			stmt.Args = []string{}
+		}
+		for _, i := range msg.GetIncludes() {
+			stmt.Includes = append(stmt.Includes, int(i))
+		}
+		for _, b := range msg.GetArgBreaks() {
+			stmt.ArgBreaks = append(stmt.ArgBreaks, int(b))
+		}
+		for _, b := range msg.GetLeadingCommentBlankLines() {
+			stmt.LeadingCommentBlankLines = append(stmt.LeadingCommentBlankLines, int(b))
+		}
+		for _, c := range msg.GetArgComments() {
+			stmt.ArgComments = append(stmt.ArgComments, crossplane.ArgComment{Index: int(c.GetIndex()), Comment: c.GetComment()})
+		}
+		if msg.GetIsBlock() {
+			stmt.Block = append(crossplane.Directives{}, ToDirectives(msg.GetBlock())...)
+		}
+		d = append(d, stmt)
+	}
+	return d
+}
+
+// FromError converts an error to its protobuf message, keeping the fields of a
+// crossplane.ParseError.
+func FromError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	msg := &Error{Message: err.Error()}
+	var perr *crossplane.ParseError
+	if errors.As(err, &perr) {
+		msg.ParseError = true
+		msg.What = perr.What
+		msg.File = perr.File
+		msg.Line = int32Ptr(perr.Line)
+		msg.Statement = perr.Statement
+		msg.BlockCtx = perr.BlockCtx
+	}
+	return msg
+}
+
+// ToError converts a protobuf message back to an error. Parse errors become a
+// *crossplane.ParseError with the same message, without the error it wrapped.
+func ToError(msg *Error) error {
+	if msg == nil {
+		return nil
+	}
+	if !msg.GetParseError() {
+		return errors.New(msg.GetMessage())
+	}
+	return &crossplane.ParseError{
+		What:      msg.GetWhat(),
+		File:      msg.File,
+		Line:      intPtr(msg.Line),
+		Statement: msg.GetStatement(),
+		BlockCtx:  msg.GetBlockCtx(),
+	}
+}
@@ -0,0 +1,93 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplanepb
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	crossplane "github.com/nginxinc/nginx-go-crossplane"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func getTestConfigPath(parts ...string) string {
+	return filepath.Join("..", "testdata", "configs", filepath.Join(parts...))
+}
+
+//nolint:gochecknoglobals
+var fixtures = []struct {
+	name    string
+	options crossplane.ParseOptions
+}{
+	{"simple", crossplane.ParseOptions{}},
+	{"with-comments", crossplane.ParseOptions{ParseComments: true}},
+	{"includes-globbed", crossplane.ParseOptions{}},
+	{"includes-regular", crossplane.ParseOptions{}},
+	{"empty-config", crossplane.ParseOptions{}},
+	{"attached-comments", crossplane.ParseOptions{AttachComments: true}},
+	{"formatting", crossplane.ParseOptions{KeepFormatting: true}},
+	{"formatting", crossplane.ParseOptions{AttachComments: true, KeepFormatting: true}},
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, fixture := range fixtures {
+		fixture := fixture
+		t.Run(fixture.name, func(t *testing.T) {
+			t.Parallel()
+			payload, err := crossplane.Parse(getTestConfigPath(fixture.name, "nginx.conf"), &fixture.options)
+			require.NoError(t, err)
+
+			b, err := proto.Marshal(FromPayload(payload))
+			require.NoError(t, err)
+			var msg Payload
+			require.NoError(t, proto.Unmarshal(b, &msg))
+			converted := ToPayload(&msg)
+
+			expected, err := json.Marshal(payload)
+			require.NoError(t, err)
+			got, err := json.Marshal(converted)
+			require.NoError(t, err)
+			require.JSONEq(t, string(expected), string(got))
+
+			for i, e := range payload.Errors {
+				require.Equal(t, e.Error.Error(), converted.Errors[i].Error.Error())
+				require.IsType(t, &crossplane.ParseError{}, converted.Errors[i].Error)
+			}
+		})
+	}
+}
+
+func TestEmptyBlock(t *testing.T) {
+	t.Parallel()
+	d := crossplane.Directives{{Directive: "events", Args: []string{}, Block: crossplane.Directives{}}}
+	converted := ToDirectives(FromDirectives(d))
+	require.True(t, converted[0].IsBlock())
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+	schema, err := jsonschema.Compile(filepath.Join("..", "schema", "payload.schema.json"))
+	require.NoError(t, err)
+
+	for _, fixture := range fixtures {
+		payload, err := crossplane.Parse(getTestConfigPath(fixture.name, "nginx.conf"), &fixture.options)
+		require.NoError(t, err)
+		b, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		var doc interface{}
+		require.NoError(t, json.Unmarshal(b, &doc))
+		require.NoError(t, schema.Validate(doc), fixture.name)
+	}
+
+	require.Error(t, schema.Validate(map[string]interface{}{"status": "ok"}))
+}
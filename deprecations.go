@@ -0,0 +1,357 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Deprecation describes a directive, or a parameter of a directive, that is deprecated or
+// was removed, and what to use instead.
+type Deprecation struct {
+	Directive string
+
+	// Arg limits the deprecation to the uses of Directive with this argument, like "spdy"
+	// for the listen directive. Empty means every use of Directive.
+	Arg string
+
+	// Contexts limits the deprecation to the uses of Directive in these contexts. Zero
+	// means every context.
+	Contexts ContextMask
+
+	// Product is the product whose versions DeprecatedIn and RemovedIn are. Deprecations
+	// of ProductOSS apply to NGINX Plus as well.
+	Product Product
+
+	// DeprecatedIn is the first version that warns about the directive, and RemovedIn
+	// the first version that rejects it. Either may be empty when not known or not yet.
+	DeprecatedIn string
+	RemovedIn    string
+
+	// Replacement is the directive or parameter to use instead, like `listen ... ssl`.
+	Replacement string
+
+	// Hint explains how to migrate when Replacement alone doesn't say it.
+	Hint string
+}
+
+// the specs of the deprecated and removed directives of NGINX and NGINX Plus, for their
+// deprecations
+//
+//nolint:gochecknoglobals
+var deprecatedSpecs = []DirectiveSpec{
+	{
+		Name: "ssl", Contexts: ContextHTTP | ContextHTTPServer | ContextMail | ContextMailServer, Args: ArgsFlag,
+		Module: string(ProductOSS), MaxVersion: "1.25.0",
+		Deprecations: []Deprecation{{
+			DeprecatedIn: "1.15.0",
+			RemovedIn:    "1.25.1",
+			Replacement:  "listen ... ssl",
+			Hint:         `add the "ssl" parameter to the listen directives of the server instead`,
+		}},
+	},
+	{
+		Name: "listen", Contexts: ContextHTTPServer, Args: Args1OrMore, Module: string(ProductOSS),
+		Deprecations: []Deprecation{
+			{
+				Arg:         "spdy",
+				RemovedIn:   "1.9.5",
+				Replacement: "http2 on",
+				Hint:        `SPDY was replaced by HTTP/2, enable it with "http2 on;" in the server`,
+			},
+			{
+				Arg:          "http2",
+				DeprecatedIn: "1.25.1",
+				Replacement:  "http2 on",
+				Hint:         `remove the "http2" parameter and add "http2 on;" to the server`,
+			},
+		},
+	},
+	{
+		Name: "http2_push", Contexts: ContextHTTP | ContextHTTPServer | ContextHTTPLocation, Args: Args1,
+		Module: string(ProductOSS),
+		Deprecations: []Deprecation{{
+			DeprecatedIn: "1.25.1",
+			Hint:         "HTTP/2 server push is no longer supported and the directive is ignored, remove it",
+		}},
+	},
+	{
+		Name: "http2_push_preload", Contexts: ContextHTTP | ContextHTTPServer | ContextHTTPLocation, Args: ArgsFlag,
+		Module: string(ProductOSS),
+		Deprecations: []Deprecation{{
+			DeprecatedIn: "1.25.1",
+			Hint:         "HTTP/2 server push is no longer supported and the directive is ignored, remove it",
+		}},
+	},
+	{
+		Name: "http2_idle_timeout", Contexts: ContextHTTP | ContextHTTPServer, Args: Args1, Module: string(ProductOSS),
+		Deprecations: []Deprecation{{DeprecatedIn: "1.19.7", Replacement: "keepalive_timeout"}},
+	},
+	{
+		Name: "http2_max_requests", Contexts: ContextHTTP | ContextHTTPServer, Args: Args1, Module: string(ProductOSS),
+		Deprecations: []Deprecation{{DeprecatedIn: "1.19.7", Replacement: "keepalive_requests"}},
+	},
+	{
+		Name: "http2_recv_timeout", Contexts: ContextHTTP | ContextHTTPServer, Args: Args1, Module: string(ProductOSS),
+		Deprecations: []Deprecation{{DeprecatedIn: "1.19.7", Replacement: "client_header_timeout"}},
+	},
+	{
+		Name: "http2_max_field_size", Contexts: ContextHTTP | ContextHTTPServer, Args: Args1, Module: string(ProductOSS),
+		Deprecations: []Deprecation{{DeprecatedIn: "1.19.7", Replacement: "large_client_header_buffers"}},
+	},
+	{
+		Name: "http2_max_header_size", Contexts: ContextHTTP | ContextHTTPServer, Args: Args1, Module: string(ProductOSS),
+		Deprecations: []Deprecation{{DeprecatedIn: "1.19.7", Replacement: "large_client_header_buffers"}},
+	},
+	{
+		// not deprecated, but usually left over from configs written for older TLS versions
+		Name: "ssl_prefer_server_ciphers", Args: ArgsFlag, Module: string(ProductOSS),
+		Contexts: ContextHTTP | ContextHTTPServer | ContextMail | ContextMailServer | ContextStream | ContextStreamServer,
+		Deprecations: []Deprecation{{
+			Hint: `it only affects TLSv1.2 and older, leave it at its default "off" unless ` +
+				`"ssl_ciphers" lists weak ciphers that clients should not pick`,
+		}},
+	},
+	{
+		Name: "status", Contexts: ContextHTTPLocation, Args: ArgsNone, Module: string(ProductPlus), MaxVersion: "R15",
+		Deprecations: []Deprecation{{
+			DeprecatedIn: "R13",
+			RemovedIn:    "R16",
+			Replacement:  "api",
+			Hint:         `the status module was replaced by the NGINX Plus API, use "api;" in a location`,
+		}},
+	},
+	{
+		Name: "status_format", Contexts: ContextHTTP | ContextHTTPServer | ContextHTTPLocation, Args: Args12,
+		Module: string(ProductPlus), MaxVersion: "R15",
+		Deprecations: []Deprecation{{
+			DeprecatedIn: "R13",
+			RemovedIn:    "R16",
+			Replacement:  "api",
+			Hint:         "the NGINX Plus API only returns JSON, remove the directive",
+		}},
+	},
+	{
+		Name: "upstream_conf", Contexts: ContextHTTPLocation, Args: ArgsNone, Module: string(ProductPlus), MaxVersion: "R15",
+		Deprecations: []Deprecation{{
+			DeprecatedIn: "R13",
+			RemovedIn:    "R16",
+			Replacement:  "api write=on",
+			Hint:         `upstreams are configured with the NGINX Plus API, use "api write=on;" in a location`,
+		}},
+	},
+}
+
+// DeprecatedRegistry returns a Registry with the specs of the deprecated and removed
+// directives of NGINX and NGINX Plus, along with their Deprecations.
+func DeprecatedRegistry() *Registry {
+	specs := make([]DirectiveSpec, 0, len(deprecatedSpecs))
+	for _, spec := range deprecatedSpecs {
+		spec.Deprecations = append([]Deprecation{}, spec.Deprecations...)
+		specs = append(specs, spec)
+	}
+	return NewRegistry(specs...)
+}
+
+// specDeprecations returns the deprecations of the specs of the same module as a spec
+// from a directive table that share one of its contexts.
+func specDeprecations(spec DirectiveSpec) []Deprecation {
+	var deprecations []Deprecation
+	for _, s := range deprecatedSpecs {
+		if s.Name == spec.Name && s.Module == spec.Module && s.Contexts&spec.Contexts != 0 {
+			deprecations = append(deprecations, s.Deprecations...)
+		}
+	}
+	return deprecations
+}
+
+// deprecations returns the Deprecations of s, with the Directive, Contexts and Product
+// they don't set taken from s.
+func (s DirectiveSpec) deprecations() []Deprecation {
+	deprecations := make([]Deprecation, 0, len(s.Deprecations))
+	for _, d := range s.Deprecations {
+		d.Directive = s.Name
+		if d.Contexts == 0 {
+			d.Contexts = s.Contexts
+		}
+		if d.Product == "" {
+			d.Product = Product(s.Module)
+		}
+		deprecations = append(deprecations, d)
+	}
+	return deprecations
+}
+
+// Deprecations returns the known deprecations of NGINX and NGINX Plus directives, those
+// of DeprecatedRegistry.
+func Deprecations() []Deprecation {
+	var deprecations []Deprecation
+	for _, spec := range DeprecatedRegistry().Specs() {
+		deprecations = append(deprecations, spec.deprecations()...)
+	}
+	return deprecations
+}
+
+// appliesTo reports whether d is about a use of stmt in ctx with product.
+func (d *Deprecation) appliesTo(stmt *Directive, ctx blockCtx, product Product, blockContexts []BlockContext) bool {
+	if d.Directive != stmt.Directive {
+		return false
+	}
+	// the product only tells NGINX from NGINX Plus, the deprecations of third-party
+	// modules are about the versions of the module
+	if product != "" && d.Product == ProductPlus && product != ProductPlus {
+		return false
+	}
+	if d.Arg != "" && !contains(stmt.Args, d.Arg) {
+		return false
+	}
+	if d.Contexts != 0 {
+		mask, ok := contextMask(ctx, blockContexts)
+		if !ok || mask&uint(d.Contexts) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// message is how a Warning describes d.
+func (d *Deprecation) message() string {
+	subject := fmt.Sprintf(`"%s" directive`, d.Directive)
+	if d.Arg != "" {
+		subject = fmt.Sprintf(`"%s" parameter of the "%s" directive`, d.Arg, d.Directive)
+	}
+	product := string(d.Product)
+	switch d.Product {
+	case "", ProductOSS:
+		product = "nginx"
+	case ProductPlus:
+		product = "NGINX Plus"
+	}
+	switch {
+	case d.DeprecatedIn != "" && d.RemovedIn != "":
+		return fmt.Sprintf("the %s is deprecated since %s %s and removed in %s", subject, product, d.DeprecatedIn, d.RemovedIn)
+	case d.RemovedIn != "":
+		return fmt.Sprintf("the %s is removed in %s %s", subject, product, d.RemovedIn)
+	case d.DeprecatedIn != "":
+		return fmt.Sprintf("the %s is deprecated since %s %s", subject, product, d.DeprecatedIn)
+	default:
+		return fmt.Sprintf("the %s is discouraged", subject)
+	}
+}
+
+// hint is the migration hint of a Warning about d.
+func (d *Deprecation) hint() string {
+	if d.Hint != "" {
+		return d.Hint
+	}
+	if d.Replacement != "" {
+		return fmt.Sprintf(`use "%s" instead`, d.Replacement)
+	}
+	return ""
+}
+
+// Warning is a problem with a directive that NGINX accepts, at least for now. Unlike a
+// ParseError, a Warning doesn't keep the config from working.
+type Warning struct {
+	File      string
+	Line      int
+	Directive string
+	BlockCtx  []string
+	Message   string
+
+	// Hint tells how to fix the problem, if known.
+	Hint string
+
+	// Deprecation is set for the warnings of CheckDeprecations.
+	Deprecation *Deprecation
+}
+
+func (w Warning) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s:%d: %s", w.File, w.Line, w.Message)
+	if w.Hint != "" {
+		fmt.Fprintf(&sb, " (%s)", w.Hint)
+	}
+	return sb.String()
+}
+
+// DeprecationOptions are the options of CheckDeprecations.
+type DeprecationOptions struct {
+	// Product selects the deprecations to check. Deprecations of ProductOSS are checked
+	// for NGINX Plus too, and those of third-party modules for both. If empty, the
+	// deprecations of every product are checked.
+	Product Product
+
+	// Registry adds the Deprecations of its specs, like those of a spec file of a
+	// third-party module, to the known ones.
+	Registry *Registry
+
+	// Deprecations adds deprecations, like those of third-party modules, to the known ones.
+	Deprecations []Deprecation
+
+	// BlockContexts are the custom block contexts of the payload, like those given to
+	// Parse in ParseOptions.BlockContexts.
+	BlockContexts []BlockContext
+}
+
+// CheckDeprecations returns a Warning for every directive of the payload that is deprecated
+// or was removed, sorted by file and line. Files are checked in the block context they
+// are included into, starting at the first Config.
+func CheckDeprecations(payload *Payload, options *DeprecationOptions) []Warning {
+	if options == nil {
+		options = &DeprecationOptions{}
+	}
+	all := Deprecations()
+	if options.Registry != nil {
+		for _, spec := range options.Registry.Specs() {
+			all = append(all, spec.deprecations()...)
+		}
+	}
+	all = append(all, options.Deprecations...)
+
+	// a deprecation may be in the specs of several versions of a directive
+	byDirective := map[string][]*Deprecation{}
+	seen := map[Deprecation]struct{}{}
+	for i := range all {
+		if _, ok := seen[all[i]]; ok {
+			continue
+		}
+		seen[all[i]] = struct{}{}
+		byDirective[all[i].Directive] = append(byDirective[all[i].Directive], &all[i])
+	}
+
+	var warnings []Warning
+	_ = newPayloadWalker(payload, options.BlockContexts, true).walk(func(site *walkSite) error {
+		file, stmt, ctx := site.file, site.stmt, site.ctx
+		for _, d := range byDirective[stmt.Directive] {
+			if !d.appliesTo(stmt, ctx, options.Product, options.BlockContexts) {
+				continue
+			}
+			warnings = append(warnings, Warning{
+				File:        file,
+				Line:        stmt.Line,
+				Directive:   stmt.Directive,
+				BlockCtx:    append([]string{}, ctx...),
+				Message:     d.message(),
+				Hint:        d.hint(),
+				Deprecation: d,
+			})
+		}
+		return nil
+	})
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if warnings[i].File != warnings[j].File {
+			return warnings[i].File < warnings[j].File
+		}
+		return warnings[i].Line < warnings[j].Line
+	})
+	return warnings
+}
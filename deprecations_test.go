@@ -0,0 +1,137 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDeprecations(t *testing.T) {
+	t.Parallel()
+	payload := parseString(t, `http {
+    http2_push_preload on;
+    server {
+        listen 443 ssl http2;
+        listen 8443 spdy;
+        ssl on;
+        http2_idle_timeout 3m;
+        location /status {
+            status;
+        }
+    }
+}
+mgmt {
+    ssl on;
+}
+`)
+
+	warnings := CheckDeprecations(payload, &DeprecationOptions{Product: ProductOSS})
+	var got []string
+	for _, w := range warnings {
+		got = append(got, w.String())
+	}
+	require.Equal(t, []string{
+		`nginx.conf:2: the "http2_push_preload" directive is deprecated since nginx 1.25.1 ` +
+			`(HTTP/2 server push is no longer supported and the directive is ignored, remove it)`,
+		`nginx.conf:4: the "http2" parameter of the "listen" directive is deprecated since nginx 1.25.1 ` +
+			`(remove the "http2" parameter and add "http2 on;" to the server)`,
+		`nginx.conf:5: the "spdy" parameter of the "listen" directive is removed in nginx 1.9.5 ` +
+			`(SPDY was replaced by HTTP/2, enable it with "http2 on;" in the server)`,
+		`nginx.conf:6: the "ssl" directive is deprecated since nginx 1.15.0 and removed in 1.25.1 ` +
+			`(add the "ssl" parameter to the listen directives of the server instead)`,
+		`nginx.conf:7: the "http2_idle_timeout" directive is deprecated since nginx 1.19.7 (use "keepalive_timeout" instead)`,
+	}, got)
+	require.Equal(t, []string{"http", "server"}, warnings[3].BlockCtx)
+	require.Equal(t, "1.25.1", warnings[3].Deprecation.RemovedIn)
+
+	// the Plus deprecations are checked for NGINX Plus and with no product
+	for _, product := range []Product{ProductPlus, ""} {
+		warnings = CheckDeprecations(payload, &DeprecationOptions{Product: product})
+		require.Len(t, warnings, 6)
+		require.Equal(t, 9, warnings[5].Line)
+		require.Equal(t, `the "status" directive is deprecated since NGINX Plus R13 and removed in R16`, warnings[5].Message)
+	}
+}
+
+func TestCheckDeprecationsCustom(t *testing.T) {
+	t.Parallel()
+	payload := parseString(t, "http {\n    my_old_directive on;\n    ssl_prefer_server_ciphers on;\n    server {\n        my_zone 1m;\n    }\n}\n")
+
+	custom := []Deprecation{{
+		Directive:    "my_old_directive",
+		Contexts:     ContextHTTP,
+		Product:      "my_module",
+		DeprecatedIn: "2.0",
+		Replacement:  "my_new_directive",
+	}}
+	warnings := CheckDeprecations(payload, &DeprecationOptions{Deprecations: custom})
+	require.Len(t, warnings, 2)
+	require.Equal(t, `the "my_old_directive" directive is deprecated since my_module 2.0`, warnings[0].Message)
+	require.Equal(t, `use "my_new_directive" instead`, warnings[0].Hint)
+	require.Equal(t, `the "ssl_prefer_server_ciphers" directive is discouraged`, warnings[1].Message)
+	require.Equal(t, ContextHTTP|ContextHTTPServer|ContextMail|ContextMailServer|ContextStream|ContextStreamServer,
+		warnings[1].Deprecation.Contexts)
+
+	// the product doesn't filter out the deprecations of third-party modules
+	for _, product := range []Product{ProductOSS, ProductPlus} {
+		warnings = CheckDeprecations(payload, &DeprecationOptions{Product: product, Deprecations: custom})
+		require.Len(t, warnings, 2, product)
+		require.Equal(t, "my_old_directive", warnings[0].Directive, product)
+	}
+
+	// the deprecations of a spec file apply to the contexts of its entry
+	registry, err := LoadRegistry(strings.NewReader(`module: my_module
+directives:
+  - name: my_zone
+    contexts: [http>server]
+    args: [take1]
+    deprecations:
+      - deprecatedIn: "2.1"
+        removedIn: "3.0"
+        hint: use my_shared_zone in http
+`))
+	require.NoError(t, err)
+	warnings = CheckDeprecations(payload, &DeprecationOptions{Product: ProductOSS, Registry: registry})
+	require.Len(t, warnings, 2)
+	warnings = warnings[1:]
+	require.Equal(t, 5, warnings[0].Line)
+	require.Equal(t, `the "my_zone" directive is deprecated since my_module 2.1 and removed in 3.0`, warnings[0].Message)
+	require.Equal(t, "use my_shared_zone in http", warnings[0].Hint)
+	require.Equal(t, ContextHTTPServer, warnings[0].Deprecation.Contexts)
+
+	require.Empty(t, CheckDeprecations(parseString(t, "events {}\n"), nil))
+}
+
+func TestRegistryDeprecations(t *testing.T) {
+	t.Parallel()
+	// the specs of the directive tables carry the deprecations of their module
+	specs, ok := OssLatestRegistry().Lookup("http2_push")
+	require.True(t, ok)
+	require.Len(t, specs[0].Deprecations, 1)
+	require.Equal(t, "1.25.1", specs[0].Deprecations[0].DeprecatedIn)
+
+	specs, ok = NginxPlusLatestRegistry().Lookup("ssl")
+	require.True(t, ok)
+	require.Empty(t, specs[0].Deprecations)
+
+	// removed directives are only in DeprecatedRegistry
+	_, ok = NginxPlusR30Registry().Lookup("upstream_conf")
+	require.False(t, ok)
+	specs, ok = DeprecatedRegistry().Lookup("upstream_conf")
+	require.True(t, ok)
+	require.Equal(t, "R16", specs[0].Deprecations[0].RemovedIn)
+
+	for _, d := range Deprecations() {
+		require.NotEmpty(t, d.Directive)
+		require.NotZero(t, d.Contexts, d.Directive)
+		require.NotEmpty(t, d.Product, d.Directive)
+	}
+}
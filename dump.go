@@ -0,0 +1,147 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	dumpHeaderPrefix = "# configuration file "
+	dumpHeaderSuffix = ":"
+)
+
+// ErrNoDumpedFiles is returned by ParseDumpOutput when the output has no config files.
+//
+//nolint:gochecknoglobals
+var ErrNoDumpedFiles = errors.New("no configuration files found in nginx -T output")
+
+// dumpedFiles holds the config files of an `nginx -T` output in the order they appear.
+type dumpedFiles struct {
+	names    []string
+	contents map[string][]byte
+}
+
+// splitDumpOutput splits the output of `nginx -T` into its config files. Each file starts
+// with a "# configuration file <path>:" line. Anything before the first file, like the
+// result of the syntax check, is ignored.
+func splitDumpOutput(r io.Reader) (*dumpedFiles, error) {
+	files := &dumpedFiles{contents: map[string][]byte{}}
+	var current *bytes.Buffer
+	var name string
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if _, ok := files.contents[name]; !ok {
+			files.names = append(files.names, name)
+		}
+		files.contents[name] = current.Bytes()
+	}
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			trimmed := strings.TrimRight(line, "\r\n")
+			if strings.HasPrefix(trimmed, dumpHeaderPrefix) && strings.HasSuffix(trimmed, dumpHeaderSuffix) {
+				flush()
+				name = strings.TrimSuffix(strings.TrimPrefix(trimmed, dumpHeaderPrefix), dumpHeaderSuffix)
+				current = &bytes.Buffer{}
+			} else if current != nil {
+				current.WriteString(line)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	flush()
+
+	if len(files.names) == 0 {
+		return nil, ErrNoDumpedFiles
+	}
+	return files, nil
+}
+
+func (f *dumpedFiles) open(path string) (io.ReadCloser, error) {
+	content, ok := f.contents[path]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (f *dumpedFiles) glob(pattern string) ([]string, error) {
+	var matches []string
+	for _, name := range f.names {
+		matched, err := filepath.Match(pattern, name)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			matches = append(matches, name)
+		}
+	}
+	return matches, nil
+}
+
+// ParseDumpOutput parses the output of `nginx -T`, which holds every config file NGINX
+// loaded, each preceded by a "# configuration file <path>:" line. The first file is parsed
+// as the main config and include directives are resolved against the files in the output
+// instead of the filesystem, so the Payload has the same files and Includes as if the
+// config had been parsed on the host it was dumped from.
+//
+// The Open and Glob fields of options are ignored.
+func ParseDumpOutput(r io.Reader, options *ParseOptions) (*Payload, error) {
+	files, err := splitDumpOutput(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing nginx -T output: %w", err)
+	}
+
+	opts := *options
+	opts.Open = files.open
+	opts.Glob = files.glob
+	return Parse(files.names[0], &opts)
+}
+
+// BuildDump builds all of the config files in a crossplane.Payload into a single stream
+// in the layout of `nginx -T`, each file preceded by a "# configuration file <path>:"
+// line and followed by an empty line. The result can be read back with ParseDumpOutput.
+func BuildDump(w io.Writer, payload *Payload, options *BuildOptions) error {
+	for _, config := range payload.Config {
+		if _, err := fmt.Fprintf(w, "%s%s%s\n", dumpHeaderPrefix, config.File, dumpHeaderSuffix); err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := Build(&buf, config, options); err != nil {
+			return err
+		}
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteByte('\n')
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDumpOutput(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dump", func(t *testing.T) {
+		t.Parallel()
+		f, err := os.Open(getTestConfigPath("dump", "nginx-T.txt"))
+		require.NoError(t, err)
+		defer f.Close()
+
+		payload, err := ParseDumpOutput(f, &ParseOptions{})
+		require.NoError(t, err)
+
+		var files []string
+		for _, c := range payload.Config {
+			files = append(files, c.File)
+		}
+		require.Equal(t, []string{
+			"/etc/nginx/nginx.conf",
+			"/etc/nginx/mime.types",
+			"/etc/nginx/conf.d/a.conf",
+			"/etc/nginx/conf.d/b.conf",
+		}, files)
+
+		http := payload.Config[0].Parsed[2]
+		require.Equal(t, []int{1}, http.Block[0].Includes)
+		require.Equal(t, 6, http.Block[0].Line)
+		require.Equal(t, []int{2, 3}, http.Block[1].Includes)
+		require.Equal(t, 2, payload.Config[3].Parsed[0].Block[0].Line)
+
+		require.Equal(t, "failed", payload.Status)
+		require.Len(t, payload.Errors, 1)
+		require.Equal(t, "/etc/nginx/conf.d/a.conf", payload.Errors[0].File)
+		require.Contains(t, payload.Errors[0].Error.Error(), "/etc/nginx/snippets/missing.conf")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		_, err := ParseDumpOutput(strings.NewReader("nginx: configuration file test failed\n"), &ParseOptions{})
+		require.ErrorIs(t, err, ErrNoDumpedFiles)
+	})
+}
+
+func TestBuildDump(t *testing.T) {
+	t.Parallel()
+	payload := &Payload{
+		Config: []Config{
+			{
+				File: "/etc/nginx/nginx.conf",
+				Parsed: Directives{
+					{Directive: "events", Args: []string{}, Line: 1, Block: Directives{}},
+					{Directive: "http", Args: []string{}, Line: 2, Block: Directives{
+						{Directive: "include", Args: []string{"conf.d/*.conf"}, Line: 3, Includes: []int{1}},
+					}},
+				},
+			},
+			{
+				File: "/etc/nginx/conf.d/default.conf",
+				Parsed: Directives{
+					{Directive: "server", Args: []string{}, Line: 1, Block: Directives{
+						{Directive: "listen", Args: []string{"80"}, Line: 2},
+					}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, BuildDump(&buf, payload, &BuildOptions{}))
+	require.Equal(t, `# configuration file /etc/nginx/nginx.conf:
+events {
+}
+http {
+    include conf.d/*.conf;
+}
+
+# configuration file /etc/nginx/conf.d/default.conf:
+server {
+    listen 80;
+}
+
+`, buf.String())
+
+	dump := buf.String()
+	parsed, err := ParseDumpOutput(&buf, &ParseOptions{})
+	require.NoError(t, err)
+	require.Len(t, parsed.Config, 2)
+	require.Equal(t, "ok", parsed.Status)
+	require.Equal(t, []int{1}, parsed.Config[0].Parsed[1].Block[0].Includes)
+
+	var rebuilt bytes.Buffer
+	require.NoError(t, BuildDump(&rebuilt, parsed, &BuildOptions{}))
+	require.Equal(t, dump, rebuilt.String())
+}
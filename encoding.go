@@ -0,0 +1,272 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"errors"
+	"io"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// payloadDoc is the document form of a Payload shared by the YAML and TOML encodings.
+// It has the same fields as the JSON form, errors are written as their message.
+// Unlike the JSON form, an empty block is kept so that "events {}" stays a block.
+type payloadDoc struct {
+	Status string            `yaml:"status" toml:"status"`
+	Errors []payloadErrorDoc `yaml:"errors" toml:"errors"`
+	Config []configDoc       `yaml:"config" toml:"config"`
+}
+
+type payloadErrorDoc struct {
+	File     string      `yaml:"file" toml:"file"`
+	Line     *int        `yaml:"line" toml:"line,omitempty"`
+	Error    string      `yaml:"error" toml:"error"`
+	Callback interface{} `yaml:"callback,omitempty" toml:"callback,omitempty"`
+}
+
+type configDoc struct {
+	File   string           `yaml:"file" toml:"file"`
+	Status string           `yaml:"status" toml:"status"`
+	Errors []configErrorDoc `yaml:"errors" toml:"errors"`
+	Parsed []*directiveDoc  `yaml:"parsed" toml:"parsed"`
+}
+
+type directiveDoc struct {
+	Directive string           `yaml:"directive" toml:"directive"`
+	Line      int              `yaml:"line" toml:"line"`
+	Args      []string         `yaml:"args" toml:"args"`
+	File      string           `yaml:"file,omitempty" toml:"file,omitempty"`
+	Includes  []int            `yaml:"includes,omitempty" toml:"includes,omitempty"`
+	Block     *[]*directiveDoc `yaml:"block,omitempty" toml:"block,omitempty"`
+	Comment   *string          `yaml:"comment,omitempty" toml:"comment,omitempty"`
+
+	LeadingComments []string     `yaml:"leadingComments,omitempty" toml:"leadingComments,omitempty"`
+	TrailingComment *string      `yaml:"trailingComment,omitempty" toml:"trailingComment,omitempty"`
+	ArgComments     []ArgComment `yaml:"argComments,omitempty" toml:"argComments,omitempty"`
+
+	BlankLinesBefore         int   `yaml:"blankLinesBefore,omitempty" toml:"blankLinesBefore,omitempty"`
+	ArgBreaks                []int `yaml:"argBreaks,omitempty" toml:"argBreaks,omitempty"`
+	LeadingCommentBlankLines []int `yaml:"leadingCommentBlankLines,omitempty" toml:"leadingCommentBlankLines,omitempty"`
+}
+
+type configErrorDoc struct {
+	Line  *int   `yaml:"line" toml:"line,omitempty"`
+	Error string `yaml:"error" toml:"error"`
+}
+
+// directivesDoc wraps directives for TOML, which needs a table at the top level.
+type directivesDoc struct {
+	Directives []*directiveDoc `toml:"directives"`
+}
+
+func newDirectiveDocs(d Directives) []*directiveDoc {
+	docs := make([]*directiveDoc, 0, len(d))
+	for _, stmt := range d {
+		doc := &directiveDoc{
+			Directive: stmt.Directive,
+			Line:      stmt.Line,
+			Args:      stmt.Args,
+			File:      stmt.File,
+			Includes:  stmt.Includes,
+			Comment:   stmt.Comment,
+
+			LeadingComments: stmt.LeadingComments,
+			TrailingComment: stmt.TrailingComment,
+			ArgComments:     stmt.ArgComments,
+
+			BlankLinesBefore:         stmt.BlankLinesBefore,
+			ArgBreaks:                stmt.ArgBreaks,
+			LeadingCommentBlankLines: stmt.LeadingCommentBlankLines,
+		}
+		if stmt.Args == nil {
+			doc.Args = []string{}
+		}
+		if stmt.IsBlock() {
+			block := newDirectiveDocs(stmt.Block)
+			doc.Block = &block
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// directivesFromDocs converts the documents back, giving every directive a non-nil Args like Parse does.
+func directivesFromDocs(docs []*directiveDoc) Directives {
+	d := make(Directives, 0, len(docs))
+	for _, doc := range docs {
+		stmt := &Directive{
+			Directive: doc.Directive,
+			Line:      doc.Line,
+			Args:      doc.Args,
+			File:      doc.File,
+			Includes:  doc.Includes,
+			Comment:   doc.Comment,
+
+			LeadingComments: doc.LeadingComments,
+			TrailingComment: doc.TrailingComment,
+			ArgComments:     doc.ArgComments,
+
+			BlankLinesBefore:         doc.BlankLinesBefore,
+			ArgBreaks:                doc.ArgBreaks,
+			LeadingCommentBlankLines: doc.LeadingCommentBlankLines,
+		}
+		if stmt.Args == nil {
+			stmt.Args = []string{}
+		}
+		if doc.Block != nil {
+			stmt.Block = directivesFromDocs(*doc.Block)
+		}
+		d = append(d, stmt)
+	}
+	return d
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func decodeError(s string) error {
+	if s == "" {
+		return nil
+	}
+	return errors.New(s)
+}
+
+func newPayloadDoc(p *Payload) payloadDoc {
+	doc := payloadDoc{
+		Status: p.Status,
+		Errors: make([]payloadErrorDoc, 0, len(p.Errors)),
+		Config: make([]configDoc, 0, len(p.Config)),
+	}
+	for _, e := range p.Errors {
+		doc.Errors = append(doc.Errors, payloadErrorDoc{File: e.File, Line: e.Line, Error: errorString(e.Error), Callback: e.Callback})
+	}
+	for _, c := range p.Config {
+		cdoc := configDoc{
+			File:   c.File,
+			Status: c.Status,
+			Errors: make([]configErrorDoc, 0, len(c.Errors)),
+			Parsed: newDirectiveDocs(c.Parsed),
+		}
+		for _, e := range c.Errors {
+			cdoc.Errors = append(cdoc.Errors, configErrorDoc{Line: e.Line, Error: errorString(e.Error)})
+		}
+		doc.Config = append(doc.Config, cdoc)
+	}
+	return doc
+}
+
+func (doc payloadDoc) payload() *Payload {
+	p := &Payload{
+		Status: doc.Status,
+		Errors: make([]PayloadError, 0, len(doc.Errors)),
+		Config: make([]Config, 0, len(doc.Config)),
+	}
+	for _, e := range doc.Errors {
+		p.Errors = append(p.Errors, PayloadError{File: e.File, Line: e.Line, Error: decodeError(e.Error), Callback: e.Callback})
+	}
+	for _, c := range doc.Config {
+		config := Config{
+			File:   c.File,
+			Status: c.Status,
+			Errors: make([]ConfigError, 0, len(c.Errors)),
+			Parsed: directivesFromDocs(c.Parsed),
+		}
+		for _, e := range c.Errors {
+			config.Errors = append(config.Errors, ConfigError{Line: e.Line, Error: decodeError(e.Error)})
+		}
+		p.Config = append(p.Config, config)
+	}
+	return p
+}
+
+// decodeAndValidate finishes decoding a payload by checking it with Validate.
+func decodeAndValidate(doc payloadDoc, options *ParseOptions) (*Payload, error) {
+	payload := doc.payload()
+	if options == nil {
+		options = &ParseOptions{}
+	}
+	if err := Validate(payload, options); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// EncodeYAML writes the payload as YAML with the same fields as its JSON form.
+func EncodeYAML(w io.Writer, payload *Payload) error {
+	enc := yaml.NewEncoder(w)
+	if err := enc.Encode(newPayloadDoc(payload)); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// DecodeYAML reads a payload written by EncodeYAML and checks its directives with
+// Validate using the given options, so that it can be built safely.
+func DecodeYAML(r io.Reader, options *ParseOptions) (*Payload, error) {
+	var doc payloadDoc
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return decodeAndValidate(doc, options)
+}
+
+// EncodeDirectivesYAML writes the directives as a YAML sequence.
+func EncodeDirectivesYAML(w io.Writer, directives Directives) error {
+	enc := yaml.NewEncoder(w)
+	if err := enc.Encode(newDirectiveDocs(directives)); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// DecodeDirectivesYAML reads directives written by EncodeDirectivesYAML. Since a list of
+// directives doesn't say which block it belongs to, use ValidateDirectives to check it.
+func DecodeDirectivesYAML(r io.Reader) (Directives, error) {
+	var docs []*directiveDoc
+	if err := yaml.NewDecoder(r).Decode(&docs); err != nil {
+		return nil, err
+	}
+	return directivesFromDocs(docs), nil
+}
+
+// EncodeTOML writes the payload as TOML with the same fields as its JSON form. Lines
+// of errors that have none are left out since TOML has no null value.
+func EncodeTOML(w io.Writer, payload *Payload) error {
+	return toml.NewEncoder(w).Encode(newPayloadDoc(payload))
+}
+
+// DecodeTOML reads a payload written by EncodeTOML and checks its directives with
+// Validate using the given options, so that it can be built safely.
+func DecodeTOML(r io.Reader, options *ParseOptions) (*Payload, error) {
+	var doc payloadDoc
+	if _, err := toml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return decodeAndValidate(doc, options)
+}
+
+// EncodeDirectivesTOML writes the directives as an array of tables named "directives".
+func EncodeDirectivesTOML(w io.Writer, directives Directives) error {
+	return toml.NewEncoder(w).Encode(directivesDoc{Directives: newDirectiveDocs(directives)})
+}
+
+// DecodeDirectivesTOML reads directives written by EncodeDirectivesTOML. Since a list of
+// directives doesn't say which block it belongs to, use ValidateDirectives to check it.
+func DecodeDirectivesTOML(r io.Reader) (Directives, error) {
+	var doc directivesDoc
+	if _, err := toml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return directivesFromDocs(doc.Directives), nil
+}
@@ -0,0 +1,162 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type encodingFixture struct {
+	name   string
+	encode func(io.Writer, *Payload) error
+	decode func(io.Reader, *ParseOptions) (*Payload, error)
+}
+
+//nolint:gochecknoglobals
+var encodingFixtures = []encodingFixture{
+	{"yaml", EncodeYAML, DecodeYAML},
+	{"toml", EncodeTOML, DecodeTOML},
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, enc := range encodingFixtures {
+		enc := enc
+		for _, fixture := range []struct {
+			name    string
+			options ParseOptions
+		}{
+			{"simple", ParseOptions{}},
+			{"with-comments", ParseOptions{ParseComments: true}},
+			{"includes-globbed", ParseOptions{}},
+			{"if-check", ParseOptions{}},
+			{"types", ParseOptions{}},
+			{"attached-comments", ParseOptions{AttachComments: true}},
+			{"formatting", ParseOptions{KeepFormatting: true}},
+			{"formatting", ParseOptions{AttachComments: true, KeepFormatting: true}},
+		} {
+			fixture := fixture
+			t.Run(enc.name+"/"+fixture.name, func(t *testing.T) {
+				t.Parallel()
+				payload, err := Parse(getTestConfigPath(fixture.name, "nginx.conf"), &fixture.options)
+				require.NoError(t, err)
+
+				var buf bytes.Buffer
+				require.NoError(t, enc.encode(&buf, payload))
+				decoded, err := enc.decode(&buf, &fixture.options)
+				require.NoError(t, err)
+				require.True(t, equalPayloads(t, *payload, *decoded))
+			})
+		}
+	}
+}
+
+func TestEncodeYAML(t *testing.T) {
+	t.Parallel()
+	comment := " note"
+	payload := &Payload{
+		Status: "ok",
+		Errors: []PayloadError{},
+		Config: []Config{{
+			File:   "nginx.conf",
+			Status: "ok",
+			Errors: []ConfigError{},
+			Parsed: Directives{
+				{Directive: "#", Line: 1, Args: []string{}, Comment: &comment},
+				{Directive: "include", Line: 2, Args: []string{"conf.d/*.conf"}, Includes: []int{1}},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, EncodeYAML(&buf, payload))
+	require.Equal(t, `status: ok
+errors: []
+config:
+    - file: nginx.conf
+      status: ok
+      errors: []
+      parsed:
+        - directive: '#'
+          line: 1
+          args: []
+          comment: ' note'
+        - directive: include
+          line: 2
+          args:
+            - conf.d/*.conf
+          includes:
+            - 1
+`, buf.String())
+}
+
+func TestDecodeValidates(t *testing.T) {
+	t.Parallel()
+	doc := `status: ok
+errors: []
+config:
+    - file: nginx.conf
+      status: ok
+      errors: []
+      parsed:
+        - directive: http
+          line: 1
+          args: []
+          block:
+            - directive: listen
+              line: 2
+              args: ["80"]
+`
+	payload, err := DecodeYAML(strings.NewReader(doc), &ParseOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "failed", payload.Status)
+	require.EqualError(t, payload.Errors[0].Error, `"listen" directive is not allowed here in nginx.conf:2`)
+
+	_, err = DecodeYAML(strings.NewReader(doc), &ParseOptions{StopParsingOnError: true})
+	require.Error(t, err)
+
+	// decoding the payload with its error doesn't add it again
+	var buf bytes.Buffer
+	require.NoError(t, EncodeYAML(&buf, payload))
+	decoded, err := DecodeYAML(&buf, &ParseOptions{})
+	require.NoError(t, err)
+	require.Len(t, decoded.Errors, 1)
+	require.Len(t, decoded.Config[0].Errors, 1)
+}
+
+func TestDirectivesEncoding(t *testing.T) {
+	t.Parallel()
+	directives := Directives{
+		{Directive: "listen", Line: 1, Args: []string{"80"}},
+		{Directive: "location", Line: 2, Args: []string{"/"}, Block: Directives{
+			{Directive: "return", Line: 3, Args: []string{"200"}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, EncodeDirectivesYAML(&buf, directives))
+	decoded, err := DecodeDirectivesYAML(&buf)
+	require.NoError(t, err)
+	require.True(t, equalBlocks(directives, decoded))
+
+	buf.Reset()
+	require.NoError(t, EncodeDirectivesTOML(&buf, directives))
+	decoded, err = DecodeDirectivesTOML(&buf)
+	require.NoError(t, err)
+	require.True(t, equalBlocks(directives, decoded))
+
+	require.Empty(t, ValidateDirectives("server.yaml", decoded, []string{"http", "server"}, &ParseOptions{}))
+	errs := ValidateDirectives("server.yaml", decoded, []string{"http"}, &ParseOptions{})
+	require.Len(t, errs, 2)
+	require.EqualError(t, errs[0], `"listen" directive is not allowed here in server.yaml:1`)
+}
@@ -0,0 +1,160 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// EnvsubstOptions enable the substitution of environment variables in config files before
+// they are lexed, the way the entrypoint of the official NGINX Docker image renders its
+// templates with envsubst.
+//
+// Only the variables in Env whose names match Filter are substituted, in both the $NAME
+// and ${NAME} forms. Any other variable is left alone, so NGINX variables like $host
+// survive as long as Env doesn't define a variable with the same name. A placeholder
+// without a value is left alone too, unless ErrorOnUnset is true.
+type EnvsubstOptions struct {
+	// Env holds the environment variables available for substitution.
+	Env map[string]string
+
+	// Filter is a regular expression, like NGINX_ENVSUBST_FILTER, that the name of a
+	// variable must match to be substituted. Use "^PREFIX_" to filter by prefix. If
+	// empty, every variable in Env is substituted.
+	Filter string
+
+	// If true, a placeholder whose name matches Filter, isn't in Env and has no lowercase
+	// letters, unlike NGINX variables, is an error of the config it is in. Leave it false
+	// when the configs use uppercase NGINX variables, like those set with "set" or "map".
+	ErrorOnUnset bool
+}
+
+// envsubstResult is a config file with its environment variables substituted.
+type envsubstResult struct {
+	data []byte
+	// lines maps the lines of data to the lines of the template, lines[n] is the line
+	// of the template that produced line n.
+	lines []int
+	// unresolved holds the placeholders that had no value.
+	unresolved []*ParseError
+}
+
+// envsubst substitutes the environment variables in a template read from r. With
+// ErrorOnUnset, a $NAME or ${NAME} placeholder is reported as unresolved if NAME matches
+// the filter, has no value and has no lowercase letters, since the variables of NGINX
+// itself are all lowercase.
+//
+//nolint:gocognit
+func envsubst(r io.Reader, file string, options *EnvsubstOptions) (*envsubstResult, error) {
+	var filter *regexp.Regexp
+	if options.Filter != "" {
+		var err error
+		if filter, err = regexp.Compile(options.Filter); err != nil {
+			return nil, fmt.Errorf("invalid envsubst filter: %w", err)
+		}
+	}
+	template, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &envsubstResult{lines: []int{0, 1}}
+	var out bytes.Buffer
+	line := 1
+
+	write := func(s string, fromTemplate bool) {
+		for _, c := range []byte(s) {
+			if c == '\n' {
+				if fromTemplate {
+					line++
+				}
+				res.lines = append(res.lines, line)
+			}
+			out.WriteByte(c)
+		}
+	}
+
+	for i := 0; i < len(template); i++ {
+		if template[i] != '$' {
+			write(string(template[i]), true)
+			continue
+		}
+
+		start := i + 1
+		braced := start < len(template) && template[start] == '{'
+		if braced {
+			start++
+		}
+		end := start
+		for end < len(template) && isEnvNameChar(template[end], end == start) {
+			end++
+		}
+		name := string(template[start:end])
+		if name == "" || (braced && (end >= len(template) || template[end] != '}')) {
+			write(string(template[i]), true)
+			continue
+		}
+		placeholder := string(template[i:end])
+		if braced {
+			placeholder += "}"
+		}
+
+		if filter != nil && !filter.MatchString(name) {
+			write(placeholder, true)
+		} else if value, ok := options.Env[name]; ok {
+			write(value, false)
+		} else {
+			if options.ErrorOnUnset && strings.ToUpper(name) == name {
+				l := line
+				res.unresolved = append(res.unresolved, &ParseError{
+					What: fmt.Sprintf(`environment variable "%s" is not set`, name),
+					File: &file,
+					Line: &l,
+				})
+			}
+			write(placeholder, true)
+		}
+		i += len(placeholder) - 1
+	}
+
+	res.data = out.Bytes()
+	return res, nil
+}
+
+func isEnvNameChar(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!first && c >= '0' && c <= '9')
+}
+
+// templateLine returns the line of the template that produced the given line.
+func (res *envsubstResult) templateLine(line int) int {
+	if line >= 0 && line < len(res.lines) {
+		return res.lines[line]
+	}
+	return res.lines[len(res.lines)-1]
+}
+
+// remapLines rewrites the lines of the tokens and lexer errors to point at the template.
+func (res *envsubstResult) remapLines(in <-chan NgxToken) <-chan NgxToken {
+	out := make(chan NgxToken, tokChanCap)
+	go func() {
+		defer close(out)
+		for t := range in {
+			t.Line = res.templateLine(t.Line)
+			if perr, ok := t.Error.(*ParseError); ok && perr.Line != nil {
+				l := res.templateLine(*perr.Line)
+				perr.Line = &l
+			}
+			out <- t
+		}
+	}()
+	return out
+}
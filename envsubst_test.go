@@ -0,0 +1,108 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestEnvsubst(t *testing.T) {
+	t.Parallel()
+	path := getTestConfigPath("envsubst", "nginx.conf")
+
+	t.Run("substitutes and reports", func(t *testing.T) {
+		t.Parallel()
+		payload, err := Parse(path, &ParseOptions{Envsubst: &EnvsubstOptions{
+			Env: map[string]string{
+				"BACKEND_HOST": "10.0.0.1",
+				"BACKEND_PORT": "8080",
+				"NGINX_PORT":   "8443",
+				"HOME":         "/root",
+			},
+			Filter:       "^(BACKEND|NGINX|MISSING)_",
+			ErrorOnUnset: true,
+		}})
+		require.NoError(t, err)
+
+		http := payload.Config[0].Parsed[1]
+		server := http.Block[1]
+		require.Equal(t, []string{"10.0.0.1:8080"}, http.Block[0].Block[0].Args)
+		require.Equal(t, []string{"8443"}, server.Block[0].Args)
+		require.Equal(t, 7, server.Block[0].Line)
+		require.Equal(t, []string{"$host", "${MISSING_NAME}"}, server.Block[1].Args)
+		// HOME doesn't match the filter, so it is left alone
+		require.Equal(t, []string{"$HOME_DIR", "${HOME}"}, server.Block[2].Args)
+		require.Equal(t, []string{"X-Host", "${host}"}, server.Block[3].Block[1].Args)
+
+		require.Len(t, payload.Errors, 1)
+		require.EqualError(t, payload.Errors[0].Error, `environment variable "MISSING_NAME" is not set in `+path+`:8`)
+	})
+
+	t.Run("stop on unresolved", func(t *testing.T) {
+		t.Parallel()
+		_, err := Parse(path, &ParseOptions{
+			StopParsingOnError: true,
+			Envsubst:           &EnvsubstOptions{Env: map[string]string{}, ErrorOnUnset: true},
+		})
+		require.EqualError(t, err, `environment variable "BACKEND_HOST" is not set in `+path+`:4`)
+	})
+
+	t.Run("leaves unset alone", func(t *testing.T) {
+		t.Parallel()
+		payload, err := Parse(path, &ParseOptions{
+			StopParsingOnError: true,
+			Envsubst:           &EnvsubstOptions{Env: map[string]string{"NGINX_PORT": "8443"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "ok", payload.Status)
+		require.Empty(t, payload.Errors)
+
+		server := payload.Config[0].Parsed[1].Block[1]
+		require.Equal(t, []string{"8443"}, server.Block[0].Args)
+		require.Equal(t, []string{"$host", "${MISSING_NAME}"}, server.Block[1].Args)
+	})
+
+	t.Run("reports bare names", func(t *testing.T) {
+		t.Parallel()
+		res, err := envsubst(strings.NewReader("listen $PORT;\nroot $DIR $document_root;\nset $HOME_DIR $HOME;\n"), "t.conf", &EnvsubstOptions{
+			Env:          map[string]string{"PORT": "80"},
+			Filter:       "^(PORT|DIR)$",
+			ErrorOnUnset: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "listen 80;\nroot $DIR $document_root;\nset $HOME_DIR $HOME;\n", string(res.data))
+		require.Len(t, res.unresolved, 1)
+		require.EqualError(t, res.unresolved[0], `environment variable "DIR" is not set in t.conf:2`)
+	})
+
+	t.Run("lines point at template", func(t *testing.T) {
+		t.Parallel()
+		res, err := envsubst(strings.NewReader("a ${V};\nb;\nc ${V}\n"), "t.conf", &EnvsubstOptions{
+			Env: map[string]string{"V": "1\n2\n3"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "a 1\n2\n3;\nb;\nc 1\n2\n3\n", string(res.data))
+		require.Equal(t, []int{1, 1, 1, 2, 3, 3, 3}, res.lines[1:len(res.lines)-1])
+
+		var lines []int
+		for tok := range res.remapLines(Lex(strings.NewReader(string(res.data)))) {
+			lines = append(lines, tok.Line)
+		}
+		require.Equal(t, []int{1, 1, 1, 1, 1, 2, 2, 3, 3, 3, 3}, lines)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		t.Parallel()
+		_, err := Parse(path, &ParseOptions{Envsubst: &EnvsubstOptions{Filter: "("}})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,164 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseEvent describes a step of ParseEvents.
+type ParseEvent struct {
+	// File is the config file being parsed.
+	File string
+
+	// BlockCtx is the block context of the event, like ["http", "server"]. For the events
+	// of a block directive this is the context the directive appears in, not the context
+	// of its block.
+	BlockCtx []string
+
+	// Directive is the directive of a StartBlock, EndBlock, Directive, Comment or
+	// IncludeResolved event. The Block of a block directive is always empty since the
+	// directives inside it are reported by their own events.
+	Directive *Directive
+
+	// Includes holds the files matched by the include directive of an IncludeResolved
+	// event. They are parsed in this order once the current file is done.
+	Includes []string
+
+	// Error is the error of an Error event.
+	Error error
+}
+
+// ParseHandler holds the functions that ParseEvents calls as it parses a config. Any of
+// them may be nil. If a function returns an error, parsing stops and ParseEvents returns
+// that error.
+type ParseHandler struct {
+	// StartBlock is called when a block directive is opened, before the directives
+	// inside the block.
+	StartBlock func(ParseEvent) error
+
+	// EndBlock is called when a block directive is closed, after the directives inside
+	// the block.
+	EndBlock func(ParseEvent) error
+
+	// Directive is called for every directive that isn't a block, including the entries
+	// of map-like blocks such as map and types.
+	Directive func(ParseEvent) error
+
+	// Comment is called for every comment if ParseOptions.ParseComments is true.
+	Comment func(ParseEvent) error
+
+	// IncludeResolved is called after the Directive event of an include directive with
+	// the files it matched.
+	IncludeResolved func(ParseEvent) error
+
+	// Error is called for every error that Parse would add to the Payload.
+	Error func(ParseEvent) error
+}
+
+// the accessors below make a nil handler report no events
+
+func (h *ParseHandler) startBlock() func(ParseEvent) error {
+	if h == nil {
+		return nil
+	}
+	return h.StartBlock
+}
+
+func (h *ParseHandler) endBlock() func(ParseEvent) error {
+	if h == nil {
+		return nil
+	}
+	return h.EndBlock
+}
+
+func (h *ParseHandler) directive() func(ParseEvent) error {
+	if h == nil {
+		return nil
+	}
+	return h.Directive
+}
+
+func (h *ParseHandler) comment() func(ParseEvent) error {
+	if h == nil {
+		return nil
+	}
+	return h.Comment
+}
+
+func (h *ParseHandler) includeResolved() func(ParseEvent) error {
+	if h == nil {
+		return nil
+	}
+	return h.IncludeResolved
+}
+
+// emit calls fn with an event for the directive. An error returned by fn stops the parse.
+func (p *parser) emit(fn func(ParseEvent) error, config *Config, stmt *Directive, ctx blockCtx, includes []string) error {
+	if fn == nil || p.eventErr != nil {
+		return p.eventErr
+	}
+	p.eventErr = fn(ParseEvent{
+		File:      config.File,
+		BlockCtx:  append([]string{}, ctx...),
+		Directive: stmt,
+		Includes:  includes,
+	})
+	return p.eventErr
+}
+
+// keep adds a parsed directive to its block, unless the parse only reports events.
+func (p *parser) keep(parsed Directives, stmt *Directive) Directives {
+	if p.events != nil {
+		return parsed
+	}
+	return append(parsed, stmt)
+}
+
+// ParseEvents parses an NGINX configuration file like Parse, but instead of building a
+// Payload it calls the functions of handler as it walks through the tokens of each file.
+// No directives are kept once they are reported, so it can go through configs of any size
+// with bounded memory. Files are reported one after another in the order Parse would add
+// them to the Payload.
+//
+// The options work as they do for Parse, except for CombineConfigs, CheckReferences and
+// CheckDuplicates, which need the whole Payload and are ignored. If StopParsingOnError is
+// true, the first error is returned instead of being reported to handler.Error.
+func ParseEvents(filename string, handler *ParseHandler, options *ParseOptions) error {
+	opts := *options
+	opts.CombineConfigs = false
+	if handler == nil {
+		handler = &ParseHandler{}
+	}
+
+	var p *parser
+	handleError := func(config *Config, err error) {
+		if handler.Error == nil || p.eventErr != nil {
+			return
+		}
+		p.eventErr = handler.Error(ParseEvent{
+			File:     config.File,
+			BlockCtx: append([]string{}, p.ctx...),
+			Error:    err,
+		})
+	}
+	p = newParser(filename, &opts, handleError)
+	p.events = handler
+
+	for len(p.includes) > 0 {
+		if _, err := p.parseNextFile(); err != nil {
+			return err
+		}
+	}
+
+	if cycle := p.includeGraph.Cycle(); cycle != nil {
+		return fmt.Errorf("configs contain include cycle: %s", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
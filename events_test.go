@@ -0,0 +1,212 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordEvents returns a handler that describes every event it gets as a line in events.
+func recordEvents(events *[]string) *ParseHandler {
+	record := func(kind string) func(ParseEvent) error {
+		return func(e ParseEvent) error {
+			line := fmt.Sprintf("%s %s [%s]", kind, filepath.Base(e.File), strings.Join(e.BlockCtx, ">"))
+			switch {
+			case e.Error != nil:
+				line += " " + e.Error.Error()
+			case e.Directive.IsComment():
+				line += fmt.Sprintf(" #%s:%d", *e.Directive.Comment, e.Directive.Line)
+			default:
+				line += fmt.Sprintf(" %s:%d", e.Directive.Directive, e.Directive.Line)
+			}
+			for _, f := range e.Includes {
+				line += " " + filepath.Base(f)
+			}
+			*events = append(*events, line)
+			return nil
+		}
+	}
+	return &ParseHandler{
+		StartBlock:      record("start"),
+		EndBlock:        record("end"),
+		Directive:       record("directive"),
+		Comment:         record("comment"),
+		IncludeResolved: record("include"),
+		Error:           record("error"),
+	}
+}
+
+func TestParseEvents(t *testing.T) {
+	t.Parallel()
+	for _, fixture := range []struct {
+		name    string
+		options ParseOptions
+		events  []string
+	}{
+		{
+			"includes-regular",
+			ParseOptions{},
+			[]string{
+				"start nginx.conf [] events:1",
+				"end nginx.conf [] events:1",
+				"start nginx.conf [] http:2",
+				"directive nginx.conf [http] include:3",
+				"include nginx.conf [http] include:3 server.conf",
+				"end nginx.conf [] http:2",
+				"start server.conf [http] server:1",
+				"directive server.conf [http>server] listen:2",
+				"directive server.conf [http>server] server_name:3",
+				"directive server.conf [http>server] include:4",
+				"include server.conf [http>server] include:4 foo.conf",
+				"error server.conf [http>server] open testdata/configs/includes-regular/bar.conf: " +
+					"no such file or directory in testdata/configs/includes-regular/conf.d/server.conf:5",
+				"directive server.conf [http>server] include:5",
+				"include server.conf [http>server] include:5",
+				"end server.conf [http] server:1",
+				"start foo.conf [http>server] location:1",
+				"directive foo.conf [http>location] return:2",
+				"end foo.conf [http>server] location:1",
+			},
+		},
+		{
+			"with-comments",
+			ParseOptions{ParseComments: true},
+			[]string{
+				"start nginx.conf [] events:1",
+				"directive nginx.conf [events] worker_connections:2",
+				"end nginx.conf [] events:1",
+				"comment nginx.conf [] #comment:4",
+				"start nginx.conf [] http:5",
+				"start nginx.conf [http] server:6",
+				"directive nginx.conf [http>server] listen:7",
+				"comment nginx.conf [http>server] #listen:7",
+				"directive nginx.conf [http>server] server_name:8",
+				"start nginx.conf [http>server] location:9",
+				"comment nginx.conf [http>location] ## this is brace:9",
+				"comment nginx.conf [http>location] # location /:10",
+				"directive nginx.conf [http>location] return:11",
+				"end nginx.conf [http>server] location:9",
+				"end nginx.conf [http] server:6",
+				"end nginx.conf [] http:5",
+			},
+		},
+		{
+			"types",
+			ParseOptions{},
+			[]string{
+				"start nginx.conf [] http:1",
+				"start nginx.conf [http] types:2",
+				"directive nginx.conf [http>types] text/html:3",
+				"directive nginx.conf [http>types] text/css:4",
+				"end nginx.conf [http] types:2",
+				"end nginx.conf [] http:1",
+			},
+		},
+	} {
+		fixture := fixture
+		t.Run(fixture.name, func(t *testing.T) {
+			t.Parallel()
+			var events []string
+			err := ParseEvents(getTestConfigPath(fixture.name, "nginx.conf"), recordEvents(&events), &fixture.options)
+			require.NoError(t, err)
+			require.Equal(t, fixture.events, events)
+		})
+	}
+}
+
+func TestParseEventsMatchParse(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"simple", "includes-globbed", "messy", "spelling-mistake", "invalid-map"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			path := getTestConfigPath(name, "nginx.conf")
+			payload, err := Parse(path, &ParseOptions{ParseComments: true, ErrorOnUnknownDirectives: true})
+			require.NoError(t, err)
+
+			// rebuild the tree of every file from the events
+			configs := map[string]*Directives{}
+			var stack []*Directives
+			var errs []string
+			top := func(e ParseEvent) *Directives {
+				if len(stack) > 0 {
+					return stack[len(stack)-1]
+				}
+				if _, ok := configs[e.File]; !ok {
+					configs[e.File] = &Directives{}
+				}
+				return configs[e.File]
+			}
+			add := func(e ParseEvent) error {
+				block := top(e)
+				*block = append(*block, e.Directive)
+				return nil
+			}
+			err = ParseEvents(path, &ParseHandler{
+				StartBlock: func(e ParseEvent) error {
+					_ = add(e)
+					stack = append(stack, &e.Directive.Block)
+					return nil
+				},
+				EndBlock: func(e ParseEvent) error {
+					stack = stack[:len(stack)-1]
+					return nil
+				},
+				Directive: add,
+				Comment:   add,
+				Error: func(e ParseEvent) error {
+					errs = append(errs, e.Error.Error())
+					return nil
+				},
+			}, &ParseOptions{ParseComments: true, ErrorOnUnknownDirectives: true})
+			require.NoError(t, err)
+
+			var payloadErrs []string
+			for _, e := range payload.Errors {
+				payloadErrs = append(payloadErrs, e.Error.Error())
+			}
+			require.Equal(t, payloadErrs, errs)
+			for _, config := range payload.Config {
+				if len(config.Parsed) == 0 {
+					continue
+				}
+				got, ok := configs[config.File]
+				require.True(t, ok, config.File)
+				require.True(t, equalBlocks(config.Parsed, *got), config.File)
+			}
+		})
+	}
+}
+
+func TestParseEventsStop(t *testing.T) {
+	t.Parallel()
+	errStop := errors.New("stop")
+	var directives []string
+	err := ParseEvents(getTestConfigPath("simple", "nginx.conf"), &ParseHandler{
+		Directive: func(e ParseEvent) error {
+			directives = append(directives, e.Directive.Directive)
+			if len(directives) == 2 {
+				return errStop
+			}
+			return nil
+		},
+	}, &ParseOptions{})
+	require.ErrorIs(t, err, errStop)
+	require.Len(t, directives, 2)
+
+	err = ParseEvents(getTestConfigPath("spelling-mistake", "nginx.conf"), &ParseHandler{
+		Error: func(e ParseEvent) error { return e.Error },
+	}, &ParseOptions{ErrorOnUnknownDirectives: true})
+	require.EqualError(t, err, `unknown directive "proxy_passs" in testdata/configs/spelling-mistake/nginx.conf:7`)
+}
@@ -0,0 +1,181 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// FormatOptions determine the behavior of Format.
+type FormatOptions struct {
+	// Indent is the number of spaces per level of indentation, 4 if zero.
+	Indent int
+
+	// If true, indent with tabs instead of spaces.
+	Tabs bool
+
+	// Builders are used to write the directives that Format can't, like those lexed by
+	// the lexers of LexOptions.
+	Builders []RegisterBuilder
+
+	LexOptions LexOptions
+}
+
+const formatFilename = "nginx.conf"
+
+// Format returns the canonical form of the NGINX config read from r. It only looks at the
+// tokens of the config, so directives don't need to be known and includes aren't followed.
+//
+// Comments stay with the directives they belong to, runs of blank lines are collapsed
+// into one and the blank lines at the start of a block are removed. Every directive is on
+// its own line, indented by its depth, and its arguments are quoted only when needed, like
+// Enquote does. Line breaks between arguments are kept. The entries of map-like blocks,
+// such as map, types and geo, are aligned in a column. Formatting the output of Format
+// gives the same output.
+//
+// Errors refer to the config as nginx.conf.
+func Format(r io.Reader, options *FormatOptions) ([]byte, error) {
+	return format(formatFilename, r, options)
+}
+
+func format(filename string, r io.Reader, options *FormatOptions) ([]byte, error) {
+	if options == nil {
+		options = &FormatOptions{}
+	}
+
+	payload, err := Parse(filename, &ParseOptions{
+		Open:           func(string) (io.ReadCloser, error) { return io.NopCloser(r), nil },
+		SingleFile:     true,
+		AttachComments: true,
+		KeepFormatting: true,
+		// no directive is known, so none are checked against their contexts and arguments
+		DirectiveSources:   []MatchFunc{func(string) ([]uint, bool) { return nil, false }},
+		StopParsingOnError: true,
+		LexOptions:         options.LexOptions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(payload.Errors) > 0 {
+		return nil, payload.Errors[0].Error
+	}
+
+	config := payload.Config[0]
+	// no directive is known, so an empty "if" expression isn't reported by Parse
+	if err := checkIfExprs(config.File, config.Parsed); err != nil {
+		return nil, err
+	}
+	line := 0
+	normalizeFormat(config.Parsed, &line)
+
+	alignBlocks := make([]string, 0, len(mapBodies))
+	for name := range mapBodies {
+		alignBlocks = append(alignBlocks, name)
+	}
+	sort.Strings(alignBlocks)
+
+	var buf bytes.Buffer
+	if err := Build(&buf, config, &BuildOptions{
+		Indent:         options.Indent,
+		Tabs:           options.Tabs,
+		KeepBlankLines: true,
+		KeepArgBreaks:  true,
+		AlignBlocks:    alignBlocks,
+		Builders:       options.Builders,
+	}); err != nil {
+		return nil, err
+	}
+	if buf.Len() > 0 {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// checkIfExprs returns an error for the first "if" directive of block, or of the blocks in
+// it, whose expression is empty.
+func checkIfExprs(file string, block Directives) error {
+	for _, stmt := range block {
+		if stmt.Directive == "if" && len(stmt.Args) == 0 {
+			line := stmt.Line
+			return &ParseError{
+				What:      `directive "if" has an empty expression`,
+				File:      &file,
+				Line:      &line,
+				Statement: "if ()",
+			}
+		}
+		if err := checkIfExprs(file, stmt.Block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeFormat collapses the blank lines of block and numbers its directives one per
+// line, so that no comment is written on the line of the directive before it.
+func normalizeFormat(block Directives, line *int) {
+	for i, stmt := range block {
+		*line++
+		stmt.Line = *line
+		if i == 0 {
+			stmt.BlankLinesBefore = 0
+		} else if stmt.BlankLinesBefore > 1 {
+			stmt.BlankLinesBefore = 1
+		}
+		for j, n := range stmt.LeadingCommentBlankLines {
+			if n > 1 {
+				stmt.LeadingCommentBlankLines[j] = 1
+			}
+		}
+		normalizeFormat(stmt.Block, line)
+	}
+}
+
+// CheckFormat reports whether the NGINX config read from r is already formatted like
+// Format would format it. If it isn't, CheckFormat also returns a unified diff from the
+// config to its formatted form, which uses filename as the name of the file.
+func CheckFormat(filename string, r io.Reader, options *FormatOptions) (bool, string, error) {
+	orig, err := io.ReadAll(r)
+	if err != nil {
+		return false, "", err
+	}
+	formatted, err := format(filename, bytes.NewReader(orig), options)
+	if err != nil {
+		return false, "", err
+	}
+	if bytes.Equal(orig, formatted) {
+		return true, "", nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(string(orig)),
+		B:        splitLines(string(formatted)),
+		FromFile: filename,
+		ToFile:   filename + " (formatted)",
+		Context:  3,
+	})
+	if err != nil {
+		return false, "", err
+	}
+	return false, diff, nil
+}
+
+// splitLines splits s into lines that keep their "\n". Unlike difflib.SplitLines, a final
+// "\n" doesn't add an empty line.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
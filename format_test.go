@@ -0,0 +1,175 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const formattedConfig = `# global settings
+user nginx;
+worker_processes auto; # one per core
+
+events {
+    worker_connections 1024;
+}
+http {
+    include mime.types;
+    map $http_host $backend {
+        default     upstream_a;
+        example.com upstream_b;
+        # internal hosts
+        ~^internal\. upstream_internal;
+    }
+    log_format main "$remote_addr - $remote_user [$time_local] "
+        '"$request" $status';
+    server {
+        listen 80;
+        server_name example.com www.example.com;
+        location / {
+            if ($request_method = POST) {
+                return 405;
+            }
+            proxy_pass http://upstream_a; # the backend
+        }
+
+        # end of server
+    }
+}
+`
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+	f, err := os.Open(getTestConfigPath("unformatted", "nginx.conf"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	got, err := Format(f, nil)
+	require.NoError(t, err)
+	require.Equal(t, formattedConfig, string(got))
+
+	got, err = Format(strings.NewReader("types { text/html html; image/png png; }"), &FormatOptions{Tabs: true})
+	require.NoError(t, err)
+	require.Equal(t, "types {\n\ttext/html html;\n\timage/png png;\n}\n", string(got))
+
+	got, err = Format(strings.NewReader(""), nil)
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	_, err = Format(strings.NewReader("http {\n"), nil)
+	require.EqualError(t, err, `unexpected end of file, expecting "}" in nginx.conf:2`)
+}
+
+func TestFormatCommentBlankLines(t *testing.T) {
+	t.Parallel()
+	got, err := Format(strings.NewReader("http {\n    # section header\n\n    server_tokens off;\n}\n"), nil)
+	require.NoError(t, err)
+	require.Equal(t, "http {\n    # section header\n\n    server_tokens off;\n}\n", string(got))
+
+	// runs of blank lines between comments are collapsed too
+	got, err = Format(strings.NewReader("http {\n    sendfile on;\n\n\n    # a\n\n\n    # b\n    # c\n\n\n\n    server_tokens off;\n}\n"), nil)
+	require.NoError(t, err)
+	require.Equal(t, "http {\n    sendfile on;\n\n    # a\n\n    # b\n    # c\n\n    server_tokens off;\n}\n", string(got))
+}
+
+func TestFormatIdempotent(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{
+		"unformatted", "attached-comments", "formatting", "with-comments", "messy",
+		"simple", "quoted-right-brace", "types", "geo", "if-expr", "empty-value-map", "comments-between-args",
+	} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			b, err := os.ReadFile(getTestConfigPath(name, "nginx.conf"))
+			require.NoError(t, err)
+
+			once, err := Format(bytes.NewReader(b), nil)
+			require.NoError(t, err)
+			twice, err := Format(bytes.NewReader(once), nil)
+			require.NoError(t, err)
+			require.Equal(t, string(once), string(twice))
+
+			// formatting keeps the directives and comments
+			payload, err := Parse(name, &ParseOptions{
+				Open:          func(string) (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(b)), nil },
+				SingleFile:    true,
+				ParseComments: true,
+			})
+			require.NoError(t, err)
+			formatted, err := Parse(name, &ParseOptions{
+				Open:          func(string) (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(once)), nil },
+				SingleFile:    true,
+				ParseComments: true,
+			})
+			require.NoError(t, err)
+			require.True(t, equalBlocks(stripLines(payload.Config[0].Parsed), stripLines(formatted.Config[0].Parsed)))
+		})
+	}
+}
+
+func TestCheckFormat(t *testing.T) {
+	t.Parallel()
+	ok, diff, err := CheckFormat("nginx.conf", strings.NewReader(formattedConfig), nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Empty(t, diff)
+
+	ok, diff, err = CheckFormat("nginx.conf", strings.NewReader("events {}\nhttp {\n  sendfile   on;\n}\n"), nil)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, `--- nginx.conf
++++ nginx.conf (formatted)
+@@ -1,4 +1,5 @@
+-events {}
++events {
++}
+ http {
+-  sendfile   on;
++    sendfile on;
+ }
+`, diff)
+
+	_, _, err = CheckFormat("broken.conf", strings.NewReader("}"), nil)
+	require.EqualError(t, err, `unexpected "}" in broken.conf:1`)
+}
+
+func TestFormatEmptyIf(t *testing.T) {
+	t.Parallel()
+	for name, config := range map[string]string{
+		"empty parens":        "server {\n    if () {\n        return 404;\n    }\n}\n",
+		"empty spaced parens": "server {\n    if ( ) {\n        return 404;\n    }\n}\n",
+	} {
+		config := config
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Format(strings.NewReader(config), nil)
+			require.EqualError(t, err, `directive "if" has an empty expression in nginx.conf:2`)
+		})
+	}
+
+	b, err := os.ReadFile(getTestConfigPath("if-expr", "empty-spaced-parens.conf"))
+	require.NoError(t, err)
+	_, err = Format(bytes.NewReader(b), nil)
+	require.EqualError(t, err, `directive "if" has an empty expression in nginx.conf:9`)
+}
+
+// stripLines clears the line numbers of the directives in block.
+func stripLines(block Directives) Directives {
+	for _, stmt := range block {
+		stmt.Line = 0
+		stripLines(stmt.Block)
+	}
+	return block
+}
@@ -3,18 +3,21 @@ module github.com/nginxinc/nginx-go-crossplane
 go 1.19
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/jstemmer/go-junit-report v1.0.0
 	github.com/maxbrunsfeld/counterfeiter/v6 v6.8.1
+	github.com/pmezard/go-difflib v1.0.0
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/tools v0.23.0
+	google.golang.org/protobuf v1.33.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/kr/pretty v0.3.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/mod v0.19.0 // indirect
 	golang.org/x/sync v0.7.0 // indirect
 	golang.org/x/text v0.16.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
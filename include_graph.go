@@ -0,0 +1,192 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// IncludeGraph describes how the files of a parsed configuration include each other.
+type IncludeGraph struct {
+	// Nodes holds the paths of every parsed file. The index of a node matches the index
+	// of its Config in the Payload and the values used in Directive.Includes.
+	Nodes []string `json:"nodes"`
+
+	// Edges holds one entry for every file matched by an include directive.
+	Edges []IncludeEdge `json:"edges"`
+
+	// UnmatchedGlobs holds the include directives whose glob pattern matched no files.
+	UnmatchedGlobs []UnmatchedGlob `json:"unmatchedGlobs"`
+}
+
+// IncludeEdge is an edge of the IncludeGraph between the file containing an include
+// directive and a file it includes.
+type IncludeEdge struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Line    int    `json:"line"`
+	Pattern string `json:"pattern"`
+	// BlockCtx is the list of enclosing block directives of the include directive.
+	BlockCtx []string `json:"blockCtx"`
+}
+
+// UnmatchedGlob is an include directive whose glob pattern did not match any files.
+type UnmatchedGlob struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Pattern  string   `json:"pattern"`
+	BlockCtx []string `json:"blockCtx"`
+}
+
+func newIncludeGraph(root string) *IncludeGraph {
+	return &IncludeGraph{
+		Nodes:          []string{root},
+		Edges:          []IncludeEdge{},
+		UnmatchedGlobs: []UnmatchedGlob{},
+	}
+}
+
+func (g *IncludeGraph) addNode(file string) {
+	g.Nodes = append(g.Nodes, file)
+}
+
+func (g *IncludeGraph) addEdge(from string, to string, line int, pattern string, ctx blockCtx) {
+	g.Edges = append(g.Edges, IncludeEdge{
+		From:     from,
+		To:       to,
+		Line:     line,
+		Pattern:  pattern,
+		BlockCtx: append([]string{}, ctx...),
+	})
+}
+
+func (g *IncludeGraph) addUnmatchedGlob(file string, line int, pattern string, ctx blockCtx) {
+	g.UnmatchedGlobs = append(g.UnmatchedGlobs, UnmatchedGlob{
+		File:     file,
+		Line:     line,
+		Pattern:  pattern,
+		BlockCtx: append([]string{}, ctx...),
+	})
+}
+
+// IncludedBy returns the edges whose target is the given file.
+func (g *IncludeGraph) IncludedBy(file string) []IncludeEdge {
+	var edges []IncludeEdge
+	for _, e := range g.Edges {
+		if e.To == file {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// Includes returns the edges whose source is the given file.
+func (g *IncludeGraph) Includes(file string) []IncludeEdge {
+	var edges []IncludeEdge
+	for _, e := range g.Edges {
+		if e.From == file {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// Cycle returns the files that make up an include cycle, starting and ending with the
+// same file, or nil if the graph is acyclic.
+func (g *IncludeGraph) Cycle() []string {
+	adj := make(map[string][]string, len(g.Nodes))
+	for _, e := range g.Edges {
+		adj[e.From] = append(adj[e.From], e.To)
+	}
+	return findCycle(g.Nodes, adj)
+}
+
+// findCycle runs a depth-first search over the adjacency list and returns the first
+// cycle found, in the order the files include each other.
+func findCycle(nodes []string, adj map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(nodes))
+	var stack []string
+
+	var visit func(node string) []string
+	visit = func(node string) []string {
+		state[node] = visiting
+		stack = append(stack, node)
+		for _, next := range adj[node] {
+			switch state[next] {
+			case visiting:
+				for i, n := range stack {
+					if n == next {
+						return append(append([]string{}, stack[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[node] = visited
+		return nil
+	}
+
+	for _, node := range nodes {
+		if state[node] == unvisited {
+			if cycle := visit(node); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Every file is a node and every
+// include is an edge labeled with the line and block context of the include directive.
+// Globs that matched no files are drawn as dashed nodes.
+func (g *IncludeGraph) WriteDOT(w io.Writer) error {
+	sb := strings.Builder{}
+	sb.WriteString("digraph includes {\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&sb, "\t%s;\n", strconv.Quote(n))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&sb, "\t%s -> %s [label=%s];\n",
+			strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(edgeLabel(e.Line, e.BlockCtx)))
+	}
+	for _, u := range g.UnmatchedGlobs {
+		fmt.Fprintf(&sb, "\t%s [style=dashed];\n", strconv.Quote(u.Pattern))
+		fmt.Fprintf(&sb, "\t%s -> %s [style=dashed, label=%s];\n",
+			strconv.Quote(u.File), strconv.Quote(u.Pattern), strconv.Quote(edgeLabel(u.Line, u.BlockCtx)))
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// edgeLabel describes where an include directive is, e.g. "http>server:12".
+func edgeLabel(line int, ctx []string) string {
+	if len(ctx) == 0 {
+		return fmt.Sprintf("main:%d", line)
+	}
+	return fmt.Sprintf("%s:%d", blockCtx(ctx).key(), line)
+}
+
+// WriteJSON writes the graph as a JSON document.
+func (g *IncludeGraph) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(g)
+}
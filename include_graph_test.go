@@ -0,0 +1,113 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestIncludeGraph(t *testing.T) {
+	t.Parallel()
+
+	t.Run("globbed", func(t *testing.T) {
+		t.Parallel()
+		root := getTestConfigPath("includes-globbed", "nginx.conf")
+		payload, err := Parse(root, &ParseOptions{})
+		require.NoError(t, err)
+
+		graph := payload.IncludeGraph
+		require.NotNil(t, graph)
+		require.Equal(t, []string{
+			root,
+			getTestConfigPath("includes-globbed", "http.conf"),
+			getTestConfigPath("includes-globbed", "servers", "server1.conf"),
+			getTestConfigPath("includes-globbed", "servers", "server2.conf"),
+			getTestConfigPath("includes-globbed", "locations", "location1.conf"),
+			getTestConfigPath("includes-globbed", "locations", "location2.conf"),
+		}, graph.Nodes)
+		for i, config := range payload.Config {
+			require.Equal(t, config.File, graph.Nodes[i])
+		}
+		require.Len(t, graph.Edges, 7)
+		require.Equal(t, IncludeEdge{
+			From:     root,
+			To:       getTestConfigPath("includes-globbed", "http.conf"),
+			Line:     2,
+			Pattern:  "http.conf",
+			BlockCtx: []string{},
+		}, graph.Edges[0])
+		require.Equal(t, IncludeEdge{
+			From:     getTestConfigPath("includes-globbed", "servers", "server2.conf"),
+			To:       getTestConfigPath("includes-globbed", "locations", "location2.conf"),
+			Line:     3,
+			Pattern:  "locations/*.conf",
+			BlockCtx: []string{"http", "server"},
+		}, graph.Edges[6])
+		require.Len(t, graph.IncludedBy(getTestConfigPath("includes-globbed", "locations", "location1.conf")), 2)
+		require.Len(t, graph.Includes(getTestConfigPath("includes-globbed", "http.conf")), 2)
+		require.Empty(t, graph.UnmatchedGlobs)
+		require.Nil(t, graph.Cycle())
+	})
+
+	t.Run("unmatched glob", func(t *testing.T) {
+		t.Parallel()
+		root := getTestConfigPath("includes-unmatched-glob", "nginx.conf")
+		payload, err := Parse(root, &ParseOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []UnmatchedGlob{
+			{File: root, Line: 3, Pattern: "conf.d/*.conf", BlockCtx: []string{"http"}},
+		}, payload.IncludeGraph.UnmatchedGlobs)
+
+		var dot bytes.Buffer
+		require.NoError(t, payload.IncludeGraph.WriteDOT(&dot))
+		require.Equal(t, `digraph includes {
+	"testdata/configs/includes-unmatched-glob/nginx.conf";
+	"conf.d/*.conf" [style=dashed];
+	"testdata/configs/includes-unmatched-glob/nginx.conf" -> "conf.d/*.conf" [style=dashed, label="http:3"];
+}
+`, dot.String())
+	})
+
+	t.Run("dot and json", func(t *testing.T) {
+		t.Parallel()
+		graph := newIncludeGraph("nginx.conf")
+		graph.addNode("conf.d/a.conf")
+		graph.addEdge("nginx.conf", "conf.d/a.conf", 4, "conf.d/*.conf", blockCtx{"http"})
+
+		var dot bytes.Buffer
+		require.NoError(t, graph.WriteDOT(&dot))
+		require.Equal(t, `digraph includes {
+	"nginx.conf";
+	"conf.d/a.conf";
+	"nginx.conf" -> "conf.d/a.conf" [label="http:4"];
+}
+`, dot.String())
+
+		var buf bytes.Buffer
+		require.NoError(t, graph.WriteJSON(&buf))
+		var decoded IncludeGraph
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Equal(t, *graph, decoded)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+		graph := newIncludeGraph("nginx.conf")
+		graph.addNode("a.conf")
+		graph.addNode("b.conf")
+		graph.addEdge("nginx.conf", "a.conf", 1, "a.conf", blockCtx{})
+		graph.addEdge("a.conf", "b.conf", 1, "b.conf", blockCtx{})
+		graph.addEdge("b.conf", "a.conf", 1, "a.conf", blockCtx{})
+		require.Equal(t, []string{"a.conf", "b.conf", "a.conf"}, graph.Cycle())
+	})
+}
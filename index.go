@@ -0,0 +1,410 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EntityKind is the kind of a named entity that can be defined and referenced in an
+// NGINX configuration.
+type EntityKind string
+
+const (
+	EntityUpstream      EntityKind = "upstream"
+	EntityLimitReqZone  EntityKind = "limit_req zone"
+	EntityLimitConnZone EntityKind = "limit_conn zone"
+	EntityCacheZone     EntityKind = "cache zone"
+	EntityLogFormat     EntityKind = "log format"
+	EntityVariable      EntityKind = "variable"
+	EntityNamedLocation EntityKind = "named location"
+	EntityFile          EntityKind = "file"
+)
+
+// Site is a place in the configuration where an entity is defined or referenced.
+type Site struct {
+	File string
+	// Line is the line of Directive, or 0 for the definition of an EntityFile.
+	Line      int
+	Directive *Directive
+	// BlockCtx is the list of enclosing block directives of Directive.
+	BlockCtx []string
+
+	// server is the server block Directive is in, which scopes named locations.
+	server *Directive
+}
+
+type entityKey struct {
+	kind EntityKind
+	name string
+}
+
+// Index maps the named entities of a Payload to the sites where they are defined and
+// the sites that reference them.
+//
+// Entities are upstreams, limit_req and limit_conn zones, cache zones, log formats,
+// variables, named locations and config files. Variables are defined by map, geo and
+// split_clients; every variable used in an argument, other than regex captures like $1,
+// is recorded as a reference. Files are named by their Config.File and referenced by
+// include directives.
+type Index struct {
+	defs map[entityKey][]Site
+	refs map[entityKey][]Site
+}
+
+//nolint:gochecknoglobals
+var (
+	// directives that proxy requests to an upstream given as their first argument.
+	upstreamPassDirectives = map[string]struct{}{
+		"proxy_pass":     {},
+		"fastcgi_pass":   {},
+		"uwsgi_pass":     {},
+		"scgi_pass":      {},
+		"grpc_pass":      {},
+		"memcached_pass": {},
+	}
+
+	// directives that define a cache zone with their keys_zone= argument.
+	cachePathDirectives = map[string]struct{}{
+		"proxy_cache_path":   {},
+		"fastcgi_cache_path": {},
+		"uwsgi_cache_path":   {},
+		"scgi_cache_path":    {},
+	}
+
+	// directives that use a cache zone given as their first argument.
+	cacheDirectives = map[string]struct{}{
+		"proxy_cache":   {},
+		"fastcgi_cache": {},
+		"uwsgi_cache":   {},
+		"scgi_cache":    {},
+	}
+
+	// entities that NGINX defines without any directive.
+	predefinedEntities = map[entityKey]struct{}{
+		{EntityLogFormat, "combined"}: {},
+	}
+)
+
+// NewIndex builds an Index of the entities in the payload. Files are walked starting
+// at the first Config and following include directives, so that the block context of
+// every site is the one the file is included into. A file included several times is
+// indexed at every include, so its directives can have a site for each.
+func NewIndex(payload *Payload) *Index {
+	return newIndex(payload, nil)
+}
+
+// newIndex builds an Index with the custom block contexts of ParseOptions.BlockContexts.
+func newIndex(payload *Payload, blockContexts []BlockContext) *Index {
+	idx := &Index{
+		defs: map[entityKey][]Site{},
+		refs: map[entityKey][]Site{},
+	}
+
+	for _, config := range payload.Config {
+		idx.add(idx.defs, EntityFile, config.File, Site{File: config.File})
+	}
+
+	_ = newPayloadWalker(payload, blockContexts, false).walk(func(s *walkSite) error {
+		server := enclosingServer(s.parents)
+		site := Site{File: s.file, Line: s.stmt.Line, Directive: s.stmt, BlockCtx: append([]string{}, s.ctx...), server: server}
+		idx.addDirective(site)
+		// the values of map-like blocks can use variables
+		if _, ok := mapBodies[s.stmt.Directive]; ok {
+			entryCtx := append(append([]string{}, s.ctx...), s.stmt.Directive)
+			for _, entry := range s.stmt.Block {
+				entrySite := Site{File: s.file, Line: entry.Line, Directive: entry, BlockCtx: entryCtx, server: server}
+				idx.addVariableRefs(entrySite, entry.Args, -1)
+			}
+		}
+		for _, i := range s.stmt.Includes {
+			if i >= 0 && i < len(payload.Config) {
+				idx.add(idx.refs, EntityFile, payload.Config[i].File, site)
+			}
+		}
+		return nil
+	})
+
+	return idx
+}
+
+// enclosingServer returns the innermost server block of parents, or nil.
+func enclosingServer(parents []*Directive) *Directive {
+	for i := len(parents) - 1; i >= 0; i-- {
+		if parents[i].Directive == "server" {
+			return parents[i]
+		}
+	}
+	return nil
+}
+
+func (idx *Index) add(m map[entityKey][]Site, kind EntityKind, name string, site Site) {
+	if name == "" {
+		return
+	}
+	key := entityKey{kind, name}
+	m[key] = append(m[key], site)
+}
+
+//nolint:gocyclo
+func (idx *Index) addDirective(site Site) {
+	stmt := site.Directive
+	args := stmt.Args
+	// index of the argument that defines a variable, which is not a reference to it
+	defArg := -1
+
+	switch name := stmt.Directive; {
+	case name == "upstream" && len(args) > 0:
+		idx.add(idx.defs, EntityUpstream, args[0], site)
+	case name == "limit_req_zone":
+		idx.add(idx.defs, EntityLimitReqZone, zoneName(args, "zone="), site)
+	case name == "limit_conn_zone":
+		idx.add(idx.defs, EntityLimitConnZone, zoneName(args, "zone="), site)
+	case name == "log_format" && len(args) > 0:
+		idx.add(idx.defs, EntityLogFormat, args[0], site)
+	case name == "location" && len(args) > 0 && strings.HasPrefix(args[0], "@"):
+		idx.add(idx.defs, EntityNamedLocation, args[0], site)
+	case (name == "map" || name == "split_clients") && len(args) > 1:
+		defArg = 1
+		idx.add(idx.defs, EntityVariable, strings.TrimPrefix(args[defArg], "$"), site)
+	case name == "geo" && len(args) > 0:
+		defArg = len(args) - 1
+		idx.add(idx.defs, EntityVariable, strings.TrimPrefix(args[defArg], "$"), site)
+	case name == "limit_req":
+		idx.add(idx.refs, EntityLimitReqZone, zoneName(args, "zone="), site)
+	case name == "limit_conn" && len(args) > 0:
+		idx.add(idx.refs, EntityLimitConnZone, args[0], site)
+	case name == "access_log" && len(args) > 1 && args[0] != "off" && !strings.Contains(args[1], "="):
+		idx.add(idx.refs, EntityLogFormat, args[1], site)
+	case (name == "try_files" || name == "error_page") && len(args) > 0 && strings.HasPrefix(args[len(args)-1], "@"):
+		idx.add(idx.refs, EntityNamedLocation, args[len(args)-1], site)
+	}
+
+	if _, ok := cachePathDirectives[stmt.Directive]; ok {
+		idx.add(idx.defs, EntityCacheZone, zoneName(args, "keys_zone="), site)
+	}
+	if _, ok := cacheDirectives[stmt.Directive]; ok && len(args) > 0 && args[0] != "off" && !strings.Contains(args[0], "$") {
+		idx.add(idx.refs, EntityCacheZone, args[0], site)
+	}
+	if _, ok := upstreamPassDirectives[stmt.Directive]; ok && len(args) > 0 {
+		idx.add(idx.refs, EntityUpstream, upstreamName(args[0]), site)
+	}
+
+	idx.addVariableRefs(site, args, defArg)
+}
+
+// addVariableRefs records the variables used in args, but in args[skip], as references.
+func (idx *Index) addVariableRefs(site Site, args []string, skip int) {
+	for i, arg := range args {
+		if i == skip {
+			continue
+		}
+		for _, v := range variableNames(arg) {
+			idx.add(idx.refs, EntityVariable, v, site)
+		}
+	}
+}
+
+// zoneName returns the name of the shared memory zone in an argument like "zone=name:10m".
+func zoneName(args []string, prefix string) string {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, prefix) {
+			name, _, _ := strings.Cut(strings.TrimPrefix(arg, prefix), ":")
+			return name
+		}
+	}
+	return ""
+}
+
+// upstreamName returns the host part of an address like "http://backend:8080/path",
+// or an empty string if the address is a unix socket or contains variables.
+func upstreamName(addr string) string {
+	if strings.Contains(addr, "$") {
+		return ""
+	}
+	if _, rest, found := strings.Cut(addr, "://"); found {
+		addr = rest
+	}
+	if strings.HasPrefix(addr, "unix:") || strings.HasPrefix(addr, "[") {
+		return ""
+	}
+	addr, _, _ = strings.Cut(addr, "/")
+	addr, _, _ = strings.Cut(addr, ":")
+	return addr
+}
+
+// variableNames returns the names of the variables used in an argument, without the
+// leading "$" and enclosing braces. Regex captures like $1 aren't variables and are skipped.
+func variableNames(arg string) []string {
+	var names []string
+	for i := 0; i < len(arg); i++ {
+		if arg[i] != '$' || (i > 0 && arg[i-1] == '\\') {
+			continue
+		}
+		start := i + 1
+		braced := start < len(arg) && arg[start] == '{'
+		if braced {
+			start++
+		}
+		end := start
+		for end < len(arg) && isVariableChar(arg[end]) {
+			end++
+		}
+		if end > start && !isCapture(arg[start:end]) {
+			names = append(names, arg[start:end])
+		}
+		i = end - 1
+	}
+	return names
+}
+
+// isCapture reports whether a variable name is that of a numbered regex capture.
+func isCapture(name string) bool {
+	for i := 0; i < len(name); i++ {
+		if name[i] < '0' || name[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isVariableChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// Definitions returns the sites where the entity is defined.
+func (idx *Index) Definitions(kind EntityKind, name string) []Site {
+	return idx.defs[entityKey{kind, name}]
+}
+
+// References returns the sites where the entity is referenced.
+func (idx *Index) References(kind EntityKind, name string) []Site {
+	return idx.refs[entityKey{kind, name}]
+}
+
+// Names returns the sorted names of every defined or referenced entity of a kind.
+func (idx *Index) Names(kind EntityKind) []string {
+	seen := map[string]struct{}{}
+	for _, m := range []map[entityKey][]Site{idx.defs, idx.refs} {
+		for key := range m {
+			if key.kind == kind {
+				seen[key.name] = struct{}{}
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Dangling returns an error for every reference to an upstream, zone or log format that
+// is not defined in the same top level block (http, stream, ...), and for every reference
+// to a named location that is not defined in the same server. A reference in a file
+// included several times is reported once, if it is dangling at any of its includes.
+//
+// A proxied address is only treated as a reference to an upstream if its host has no
+// dots and is not localhost, since NGINX resolves any other host with DNS.
+func (idx *Index) Dangling() []*ParseError {
+	type reported struct {
+		key  entityKey
+		file string
+		line int
+	}
+	seen := map[reported]struct{}{}
+
+	var errs []*ParseError
+	for _, key := range idx.sortedRefKeys() {
+		if !checkedEntity(key) {
+			continue
+		}
+		defs := idx.defs[key]
+		for _, ref := range idx.refs[key] {
+			if isDefinedFor(key.kind, defs, ref) {
+				continue
+			}
+			r := reported{key, ref.File, ref.Line}
+			if _, ok := seen[r]; ok {
+				continue
+			}
+			seen[r] = struct{}{}
+			file := ref.File
+			line := ref.Line
+			errs = append(errs, &ParseError{
+				What:      fmt.Sprintf(`unknown %s "%s"`, key.kind, key.name),
+				File:      &file,
+				Line:      &line,
+				Statement: ref.Directive.String(),
+				BlockCtx:  blockCtx(ref.BlockCtx).getLastBlock(),
+			})
+		}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		if *errs[i].File != *errs[j].File {
+			return *errs[i].File < *errs[j].File
+		}
+		return *errs[i].Line < *errs[j].Line
+	})
+	return errs
+}
+
+func (idx *Index) sortedRefKeys() []entityKey {
+	keys := make([]entityKey, 0, len(idx.refs))
+	for key := range idx.refs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+		return keys[i].name < keys[j].name
+	})
+	return keys
+}
+
+func checkedEntity(key entityKey) bool {
+	if _, ok := predefinedEntities[key]; ok {
+		return false
+	}
+	switch key.kind {
+	case EntityLimitReqZone, EntityLimitConnZone, EntityCacheZone, EntityLogFormat, EntityNamedLocation:
+		return true
+	case EntityUpstream:
+		return key.name != "localhost" && !strings.Contains(key.name, ".")
+	case EntityVariable, EntityFile:
+		return false
+	}
+	return false
+}
+
+// isDefinedFor reports whether one of the definitions is in scope for the reference: in
+// the same server for a named location, and in the same top level block otherwise.
+func isDefinedFor(kind EntityKind, defs []Site, ref Site) bool {
+	for _, def := range defs {
+		if kind == EntityNamedLocation {
+			if def.server == ref.server {
+				return true
+			}
+		} else if topLevelBlock(def.BlockCtx) == topLevelBlock(ref.BlockCtx) {
+			return true
+		}
+	}
+	return false
+}
+
+func topLevelBlock(ctx []string) string {
+	if len(ctx) == 0 {
+		return ""
+	}
+	return ctx[0]
+}
@@ -0,0 +1,152 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func siteLines(sites []Site) []int {
+	lines := make([]int, 0, len(sites))
+	for _, s := range sites {
+		lines = append(lines, s.Line)
+	}
+	return lines
+}
+
+//nolint:funlen
+func TestIndex(t *testing.T) {
+	t.Parallel()
+	path := getTestConfigPath("index", "nginx.conf")
+	ssl := getTestConfigPath("index", "snippets", "ssl.conf")
+	payload, err := Parse(path, &ParseOptions{})
+	require.NoError(t, err)
+
+	idx := NewIndex(payload)
+
+	t.Run("definitions", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, []int{9}, siteLines(idx.Definitions(EntityUpstream, "payments")))
+		require.Equal(t, []int{4}, siteLines(idx.Definitions(EntityLimitReqZone, "perip")))
+		require.Equal(t, []int{5}, siteLines(idx.Definitions(EntityCacheZone, "static")))
+		require.Equal(t, []int{3}, siteLines(idx.Definitions(EntityLogFormat, "main")))
+		require.Equal(t, []int{6}, siteLines(idx.Definitions(EntityVariable, "backend_pool")))
+		require.Equal(t, []int{22}, siteLines(idx.Definitions(EntityNamedLocation, "@fallback")))
+		require.Equal(t, []Site{{File: ssl}}, idx.Definitions(EntityFile, ssl))
+	})
+
+	t.Run("references", func(t *testing.T) {
+		t.Parallel()
+		refs := idx.References(EntityUpstream, "payments")
+		require.Equal(t, []int{19, 41}, siteLines(refs))
+		require.Equal(t, []string{"http", "location"}, refs[0].BlockCtx)
+		require.Equal(t, []string{"stream", "server"}, refs[1].BlockCtx)
+
+		refs = idx.References(EntityFile, ssl)
+		require.Equal(t, []int{14, 28}, siteLines(refs))
+		require.Equal(t, path, refs[0].File)
+		require.Equal(t, []string{"http", "server"}, refs[0].BlockCtx)
+
+		require.Equal(t, []int{23}, siteLines(idx.References(EntityVariable, "backend_pool")))
+		require.Equal(t, []int{20}, siteLines(idx.References(EntityNamedLocation, "@fallback")))
+		require.Equal(t, []string{"billing", "payments"}, idx.Names(EntityUpstream))
+	})
+
+	t.Run("dangling", func(t *testing.T) {
+		t.Parallel()
+		var got []string
+		for _, err := range idx.Dangling() {
+			got = append(got, err.Error())
+		}
+		require.Equal(t, []string{
+			`unknown limit_req zone "missing" in ` + path + `:31`,
+			`unknown cache zone "other" in ` + path + `:32`,
+			`unknown upstream "billing" in ` + path + `:33`,
+			`unknown named location "@notfound" in ` + path + `:34`,
+			`unknown log format "custom" in ` + path + `:35`,
+			`unknown upstream "payments" in ` + path + `:41`,
+		}, got)
+	})
+}
+
+func TestIndexScopes(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"nginx.conf": `http {
+    upstream backend {
+        server 127.0.0.1:8080;
+    }
+    server {
+        location / {
+            include pass.conf;
+            try_files $uri @fallback;
+        }
+        location @fallback {
+            rewrite ^/(.*)$ /$1;
+        }
+    }
+    server {
+        location / {
+            try_files $uri @fallback;
+        }
+    }
+}
+stream {
+    server {
+        include pass.conf;
+    }
+}
+`,
+		"pass.conf": "proxy_pass backend;\n",
+	}
+	payload, err := Parse("nginx.conf", &ParseOptions{
+		Open: func(path string) (io.ReadCloser, error) {
+			config, ok := files[filepath.Base(path)]
+			if !ok {
+				return nil, os.ErrNotExist
+			}
+			return io.NopCloser(strings.NewReader(config)), nil
+		},
+		SkipDirectiveContextCheck: true,
+		SkipDirectiveArgsCheck:    true,
+	})
+	require.NoError(t, err)
+
+	idx := NewIndex(payload)
+
+	// the included file is indexed at both includes
+	refs := idx.References(EntityUpstream, "backend")
+	require.Len(t, refs, 2)
+	require.Equal(t, []string{"http", "location"}, refs[0].BlockCtx)
+	require.Equal(t, []string{"stream", "server"}, refs[1].BlockCtx)
+
+	// regex captures aren't variables
+	require.NotContains(t, idx.Names(EntityVariable), "1")
+
+	var got []string
+	for _, err := range idx.Dangling() {
+		got = append(got, err.Error())
+	}
+	require.Equal(t, []string{
+		`unknown named location "@fallback" in nginx.conf:16`,
+		`unknown upstream "backend" in pass.conf:1`,
+	}, got)
+}
+
+func TestVariableNames(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, []string{"a", "b_c", "d"}, variableNames(`$a-${b_c}x\$e$d`))
+	require.Equal(t, []string{"uri"}, variableNames(`/$1/$uri$2`))
+	require.Empty(t, variableNames(`no variables $`))
+}
@@ -0,0 +1,281 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package generator
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	crossplane "github.com/nginxinc/nginx-go-crossplane"
+)
+
+//nolint:gochecknoglobals
+var (
+	// the ArgKinds of the set functions of nginx
+	handlerArgKinds = map[string]crossplane.ArgKind{
+		"ngx_conf_set_flag_slot":                 crossplane.ArgKindFlag,
+		"ngx_conf_set_str_slot":                  crossplane.ArgKindString,
+		"ngx_conf_set_str_array_slot":            crossplane.ArgKindStringArray,
+		"ngx_conf_set_keyval_slot":               crossplane.ArgKindKeyval,
+		"ngx_conf_set_num_slot":                  crossplane.ArgKindNumber,
+		"ngx_conf_set_size_slot":                 crossplane.ArgKindSize,
+		"ngx_conf_set_off_slot":                  crossplane.ArgKindOffset,
+		"ngx_conf_set_msec_slot":                 crossplane.ArgKindMsec,
+		"ngx_conf_set_sec_slot":                  crossplane.ArgKindSec,
+		"ngx_conf_set_bufs_slot":                 crossplane.ArgKindBufs,
+		"ngx_conf_set_enum_slot":                 crossplane.ArgKindEnum,
+		"ngx_conf_set_bitmask_slot":              crossplane.ArgKindBitmask,
+		"ngx_conf_set_path_slot":                 crossplane.ArgKindPath,
+		"ngx_conf_set_access_slot":               crossplane.ArgKindAccess,
+		"ngx_http_set_complex_value_slot":        crossplane.ArgKindComplexValue,
+		"ngx_http_set_complex_value_size_slot":   crossplane.ArgKindComplexValue,
+		"ngx_http_set_complex_value_zero_slot":   crossplane.ArgKindComplexValue,
+		"ngx_stream_set_complex_value_slot":      crossplane.ArgKindComplexValue,
+		"ngx_stream_set_complex_value_size_slot": crossplane.ArgKindComplexValue,
+		"ngx_stream_set_complex_value_zero_slot": crossplane.ArgKindComplexValue,
+	}
+
+	// Extract the values of enum and bitmask arrays
+	// static ngx_conf_enum_t  {name}[] = {values}
+	// static ngx_conf_bitmask_t  {name}[] = {values}
+	// this regex extracts {name} and {values}.
+	valueTableExtracter = regexp.MustCompile(`ngx_conf_(?:enum|bitmask)_t\s+(\w+)\[\]\s*=\s*{(.*?})\s*}\s*;`)
+
+	valueExtracter = regexp.MustCompile(`ngx_string\("(.*?)"\)`)
+
+	// Extract the bounds of a number
+	// static ngx_conf_num_bounds_t  {name} = { ngx_conf_check_num_bounds, {min}, {max} }
+	numBoundsExtracter = regexp.MustCompile(`ngx_conf_num_bounds_t\s+(\w+)\s*=\s*{\s*ngx_conf_check_num_bounds\s*,\s*(-?\d+)\s*,\s*(-?\d+)\s*}`)
+
+	offsetofExtracter = regexp.MustCompile(`^offsetof\(\s*\w+\s*,\s*([\w.\[\]]+)\s*\)$`)
+
+	commandStartExtracter = regexp.MustCompile(`{\s*ngx_string\(`)
+
+	// Extract the name of a directive handler from its definition
+	// {name}(ngx_conf_t *cf, ngx_command_t *cmd, void *conf) {
+	handlerDefinitionExtracter = regexp.MustCompile(
+		`(\w+)\(\s*ngx_conf_t\s*\*\s*\w+\s*,\s*ngx_command_t\s*\*\s*\w+\s*,\s*void\s*\*\s*\w+\s*\)\s*{`)
+)
+
+func (s *sourceDirectives) addValueTables(content string) {
+	for _, table := range valueTableExtracter.FindAllStringSubmatch(content, -1) {
+		values := []string{}
+		for _, v := range valueExtracter.FindAllStringSubmatch(table[2], -1) {
+			values = append(values, v[1])
+		}
+		s.tables[table[1]] = values
+	}
+	for _, b := range numBoundsExtracter.FindAllStringSubmatch(content, -1) {
+		low, _ := strconv.ParseInt(b[2], 10, 64)
+		high, _ := strconv.ParseInt(b[3], 10, 64)
+		s.bounds[b[1]] = []int64{low, high}
+	}
+}
+
+// addDuplicateHandlers adds the handlers defined in content that return "is duplicate".
+// The error belongs to the last handler defined before it.
+func (s *sourceDirectives) addDuplicateHandlers(content string) {
+	handlers := handlerDefinitionExtracter.FindAllStringSubmatchIndex(content, -1)
+	h := -1
+	offset := 0
+	for {
+		i := strings.Index(content[offset:], `"is duplicate"`)
+		if i < 0 {
+			return
+		}
+		offset += i
+		for h+1 < len(handlers) && handlers[h+1][0] < offset {
+			h++
+		}
+		if h >= 0 {
+			s.duplicateHandlers[content[handlers[h][2]:handlers[h][3]]] = true
+		}
+		offset++
+	}
+}
+
+// markUnique adds ngxConfUnique to the masks of the directives that NGINX rejects with
+// "directive is duplicate" when they appear twice in a block: those set by a function
+// that keeps a single value, and those whose handler returns the error itself.
+func (s *sourceDirectives) markUnique() {
+	for name, argTypes := range s.argTypes {
+		masks := s.masks[name]
+		for i, t := range argTypes {
+			if i < len(masks) && (!t.Repeatable() || s.duplicateHandlers[t.Handler]) {
+				masks[i] = append(masks[i][:len(masks[i]):len(masks[i])], goNameUnique)
+			}
+		}
+	}
+}
+
+// resolveArgTypes sets the values and bounds of the arg types from the tables found in
+// all of the files.
+func (s *sourceDirectives) resolveArgTypes() {
+	for _, argTypes := range s.argTypes {
+		for i := range argTypes {
+			t := &argTypes[i]
+			if t.Post == "" {
+				continue
+			}
+			switch t.Kind {
+			case crossplane.ArgKindEnum, crossplane.ArgKindBitmask:
+				t.Values = s.tables[t.Post]
+			case crossplane.ArgKindNumber:
+				t.Bounds = s.bounds[t.Post]
+			}
+		}
+	}
+}
+
+// commandArgTypes returns the ArgTypes of the directives defined in the content of an
+// ngx_command_t array, which has n of them. Directives that can't be parsed, like
+// those defined with macros, have ArgKindCustom and no handler.
+func commandArgTypes(content string, n int) []crossplane.ArgType {
+	var argTypes []crossplane.ArgType
+	for _, loc := range commandStartExtracter.FindAllStringIndex(content, -1) {
+		fields, ok := splitCommand(content[loc[0]:])
+		if !ok || len(fields) != 6 {
+			argTypes = append(argTypes, crossplane.ArgType{Kind: crossplane.ArgKindCustom})
+			continue
+		}
+		argTypes = append(argTypes, argTypeOf(fields))
+	}
+
+	// the commands didn't match the directives, so none of them is trusted
+	if len(argTypes) != n {
+		argTypes = make([]crossplane.ArgType, n)
+		for i := range argTypes {
+			argTypes[i].Kind = crossplane.ArgKindCustom
+		}
+	}
+	return argTypes
+}
+
+// splitCommand splits the fields of a command that starts s, like
+// { ngx_string("name"), type, set, conf, offset, post }.
+func splitCommand(s string) ([]string, bool) {
+	var fields []string
+	depth := 0
+	start := 1
+	for i, r := range s {
+		switch r {
+		case '(', '{':
+			depth++
+		case ')', '}':
+			depth--
+		case ',':
+			if depth == 1 {
+				fields = append(fields, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+			continue
+		default:
+			continue
+		}
+		if depth == 0 {
+			return append(fields, strings.TrimSpace(s[start:i])), true
+		}
+	}
+	return nil, false
+}
+
+// argTypeOf returns the ArgType of the fields of an ngx_command_t.
+func argTypeOf(fields []string) crossplane.ArgType {
+	t := crossplane.ArgType{Kind: crossplane.ArgKindCustom}
+	if fields[2] != "0" && fields[2] != "NULL" {
+		t.Handler = fields[2]
+	}
+	if kind, ok := handlerArgKinds[t.Handler]; ok {
+		t.Kind = kind
+	}
+	if fields[3] != "0" {
+		t.Conf = fields[3]
+	}
+	if m := offsetofExtracter.FindStringSubmatch(fields[4]); m != nil {
+		t.Offset = m[1]
+	}
+	if post := strings.TrimPrefix(fields[5], "&"); post != "NULL" && post != "0" {
+		t.Post = post
+	}
+	return t
+}
+
+// writeArgTypes writes a map variable with the arg types of the directives.
+func writeArgTypes(writer io.Writer, name string, directive2ArgTypes map[string][]crossplane.ArgType) error {
+	directives := make([]string, 0, len(directive2ArgTypes))
+	for d := range directive2ArgTypes {
+		directives = append(directives, d)
+	}
+	sort.Strings(directives)
+
+	var sb strings.Builder
+	sb.WriteString("\n\n// " + name + " holds the ArgType of each mask of the directives, in the same order.\n")
+	fmt.Fprintf(&sb, "var %s = map[string][]ArgType{\n", name)
+	for _, d := range directives {
+		fmt.Fprintf(&sb, "    %q: {\n", d)
+		for _, t := range directive2ArgTypes[d] {
+			sb.WriteString("        {" + argTypeLiteral(t) + "},\n")
+		}
+		sb.WriteString("    },\n")
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(writer, sb.String())
+	return err
+}
+
+// the names of the ArgKind constants, for the generated code
+//
+//nolint:gochecknoglobals
+var argKindGoNames = map[crossplane.ArgKind]string{
+	crossplane.ArgKindFlag:         "ArgKindFlag",
+	crossplane.ArgKindString:       "ArgKindString",
+	crossplane.ArgKindStringArray:  "ArgKindStringArray",
+	crossplane.ArgKindKeyval:       "ArgKindKeyval",
+	crossplane.ArgKindNumber:       "ArgKindNumber",
+	crossplane.ArgKindSize:         "ArgKindSize",
+	crossplane.ArgKindOffset:       "ArgKindOffset",
+	crossplane.ArgKindMsec:         "ArgKindMsec",
+	crossplane.ArgKindSec:          "ArgKindSec",
+	crossplane.ArgKindBufs:         "ArgKindBufs",
+	crossplane.ArgKindEnum:         "ArgKindEnum",
+	crossplane.ArgKindBitmask:      "ArgKindBitmask",
+	crossplane.ArgKindPath:         "ArgKindPath",
+	crossplane.ArgKindAccess:       "ArgKindAccess",
+	crossplane.ArgKindComplexValue: "ArgKindComplexValue",
+	crossplane.ArgKindCustom:       "ArgKindCustom",
+}
+
+func argTypeLiteral(t crossplane.ArgType) string {
+	fields := []string{"Kind: " + argKindGoNames[t.Kind]}
+	for _, f := range []struct{ name, value string }{
+		{"Handler", t.Handler},
+		{"Conf", t.Conf},
+		{"Offset", t.Offset},
+		{"Post", t.Post},
+	} {
+		if f.value != "" {
+			fields = append(fields, fmt.Sprintf("%s: %q", f.name, f.value))
+		}
+	}
+	if t.Values != nil {
+		values := make([]string, 0, len(t.Values))
+		for _, v := range t.Values {
+			values = append(values, strconv.Quote(v))
+		}
+		fields = append(fields, "Values: []string{"+strings.Join(values, ", ")+"}")
+	}
+	if t.Bounds != nil {
+		fields = append(fields, fmt.Sprintf("Bounds: []int64{%d, %d}", t.Bounds[0], t.Bounds[1]))
+	}
+	return strings.Join(fields, ", ")
+}
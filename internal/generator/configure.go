@@ -0,0 +1,383 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// A configureModule is a part of nginx that a configure option adds or removes.
+type configureModule struct {
+	// files are the base names of the source files of the module. If it is empty, it
+	// is "ngx_" + the name of the module + ".c".
+	files []string
+
+	// dirs are the directories of the source files of the module, relative to src,
+	// like "http/v2".
+	dirs []string
+
+	// on is whether the module is built by default.
+	on bool
+
+	// defines are the feature macros nginx is built with when the module is.
+	defines []string
+
+	// parent is the module the module is part of, like "http" for "http_gzip_module".
+	parent string
+}
+
+// configureModules holds the modules of nginx that configure options can add or remove,
+// by the name used in the options: --with-http_ssl_module adds "http_ssl_module",
+// --without-http_gzip_module removes "http_gzip_module".
+//
+//nolint:gochecknoglobals
+var configureModules = map[string]configureModule{
+	"http":       {dirs: []string{"http"}, on: true},
+	"http-cache": {files: []string{"ngx_http_file_cache.c"}, on: true, defines: []string{"NGX_HTTP_CACHE"}, parent: "http"},
+	"mail":       {dirs: []string{"mail"}, defines: []string{"NGX_MAIL"}},
+	"stream":     {dirs: []string{"stream"}, defines: []string{"NGX_STREAM"}},
+
+	"http_access_module":                {on: true, parent: "http"},
+	"http_auth_basic_module":            {on: true, parent: "http"},
+	"http_autoindex_module":             {on: true, parent: "http"},
+	"http_browser_module":               {on: true, parent: "http"},
+	"http_charset_module":               {files: []string{"ngx_http_charset_filter_module.c"}, on: true, parent: "http"},
+	"http_empty_gif_module":             {on: true, parent: "http"},
+	"http_fastcgi_module":               {on: true, parent: "http"},
+	"http_geo_module":                   {on: true, defines: []string{"NGX_HTTP_X_FORWARDED_FOR"}, parent: "http"},
+	"http_grpc_module":                  {on: true, parent: "http"},
+	"http_gzip_module":                  {files: []string{"ngx_http_gzip_filter_module.c"}, on: true, defines: []string{"NGX_HTTP_GZIP"}, parent: "http"},
+	"http_limit_conn_module":            {on: true, parent: "http"},
+	"http_limit_req_module":             {on: true, parent: "http"},
+	"http_map_module":                   {on: true, parent: "http"},
+	"http_memcached_module":             {on: true, parent: "http"},
+	"http_mirror_module":                {on: true, parent: "http"},
+	"http_proxy_module":                 {on: true, parent: "http"},
+	"http_referer_module":               {on: true, parent: "http"},
+	"http_rewrite_module":               {on: true, parent: "http"},
+	"http_scgi_module":                  {on: true, parent: "http"},
+	"http_split_clients_module":         {on: true, parent: "http"},
+	"http_ssi_module":                   {files: []string{"ngx_http_ssi_filter_module.c"}, on: true, parent: "http"},
+	"http_upstream_hash_module":         {on: true, parent: "http"},
+	"http_upstream_ip_hash_module":      {on: true, parent: "http"},
+	"http_upstream_keepalive_module":    {on: true, parent: "http"},
+	"http_upstream_least_conn_module":   {on: true, parent: "http"},
+	"http_upstream_random_module":       {on: true, parent: "http"},
+	"http_upstream_zone_module":         {on: true, defines: []string{"NGX_HTTP_UPSTREAM_ZONE"}, parent: "http"},
+	"http_userid_module":                {files: []string{"ngx_http_userid_filter_module.c"}, on: true, parent: "http"},
+	"http_uwsgi_module":                 {on: true, parent: "http"},
+	"http_addition_module":              {files: []string{"ngx_http_addition_filter_module.c"}, parent: "http"},
+	"http_auth_request_module":          {parent: "http"},
+	"http_dav_module":                   {defines: []string{"NGX_HTTP_DAV"}, parent: "http"},
+	"http_degradation_module":           {defines: []string{"NGX_HTTP_DEGRADATION"}, parent: "http"},
+	"http_flv_module":                   {parent: "http"},
+	"http_geoip_module":                 {defines: []string{"NGX_HTTP_X_FORWARDED_FOR"}, parent: "http"},
+	"http_gunzip_module":                {files: []string{"ngx_http_gunzip_filter_module.c"}, defines: []string{"NGX_HTTP_GZIP"}, parent: "http"},
+	"http_gzip_static_module":           {defines: []string{"NGX_HTTP_GZIP"}, parent: "http"},
+	"http_image_filter_module":          {parent: "http"},
+	"http_mp4_module":                   {parent: "http"},
+	"http_perl_module":                  {dirs: []string{"http/modules/perl"}, parent: "http"},
+	"http_random_index_module":          {parent: "http"},
+	"http_realip_module":                {defines: []string{"NGX_HTTP_REALIP", "NGX_HTTP_X_FORWARDED_FOR"}, parent: "http"},
+	"http_secure_link_module":           {parent: "http"},
+	"http_slice_module":                 {files: []string{"ngx_http_slice_filter_module.c"}, parent: "http"},
+	"http_ssl_module":                   {defines: []string{"NGX_HTTP_SSL", "NGX_SSL", "NGX_OPENSSL"}, parent: "http"},
+	"http_stub_status_module":           {defines: []string{"NGX_STAT_STUB"}, parent: "http"},
+	"http_sub_module":                   {files: []string{"ngx_http_sub_filter_module.c"}, parent: "http"},
+	"http_v2_module":                    {dirs: []string{"http/v2"}, defines: []string{"NGX_HTTP_V2"}, parent: "http"},
+	"http_v3_module":                    {dirs: []string{"http/v3", "event/quic"}, defines: []string{"NGX_HTTP_V3", "NGX_QUIC", "NGX_SSL", "NGX_OPENSSL"}, parent: "http"},
+	"http_xslt_module":                  {files: []string{"ngx_http_xslt_filter_module.c"}, parent: "http"},
+	"mail_imap_module":                  {on: true, parent: "mail"},
+	"mail_pop3_module":                  {on: true, parent: "mail"},
+	"mail_smtp_module":                  {on: true, parent: "mail"},
+	"mail_ssl_module":                   {defines: []string{"NGX_MAIL_SSL", "NGX_SSL", "NGX_OPENSSL"}, parent: "mail"},
+	"stream_access_module":              {on: true, parent: "stream"},
+	"stream_geo_module":                 {on: true, parent: "stream"},
+	"stream_limit_conn_module":          {on: true, parent: "stream"},
+	"stream_map_module":                 {on: true, parent: "stream"},
+	"stream_pass_module":                {on: true, parent: "stream"},
+	"stream_return_module":              {on: true, parent: "stream"},
+	"stream_set_module":                 {on: true, parent: "stream"},
+	"stream_split_clients_module":       {on: true, parent: "stream"},
+	"stream_upstream_hash_module":       {on: true, parent: "stream"},
+	"stream_upstream_least_conn_module": {on: true, parent: "stream"},
+	"stream_upstream_random_module":     {on: true, parent: "stream"},
+	"stream_upstream_zone_module":       {on: true, defines: []string{"NGX_STREAM_UPSTREAM_ZONE"}, parent: "stream"},
+	"stream_geoip_module":               {parent: "stream"},
+	"stream_realip_module":              {parent: "stream"},
+	"stream_ssl_module":                 {defines: []string{"NGX_STREAM_SSL", "NGX_SSL", "NGX_OPENSSL"}, parent: "stream"},
+	"stream_ssl_preread_module":         {parent: "stream"},
+
+	// event methods and modules of src/misc, which have no or few directives
+	"poll_module":             {},
+	"select_module":           {},
+	"google_perftools_module": {},
+	"cpp_test_module":         {files: []string{"ngx_cpp_test_module.cpp"}},
+}
+
+// configureFeatures holds the configure options that only define feature macros.
+//
+//nolint:gochecknoglobals
+var configureFeatures = map[string]struct {
+	defines []string
+	on      bool
+}{
+	"pcre":     {defines: []string{"NGX_PCRE"}, on: true},
+	"threads":  {defines: []string{"NGX_THREADS"}},
+	"file-aio": {defines: []string{"NGX_HAVE_FILE_AIO"}},
+	"compat":   {defines: []string{"NGX_COMPAT"}},
+	"debug":    {defines: []string{"NGX_DEBUG"}},
+}
+
+// A Build is what the configure arguments of an nginx binary say it was built with.
+type Build struct {
+	// Version is the version from the "nginx version:" line of `nginx -V`, if any.
+	Version string
+
+	// Args are the configure arguments.
+	Args []string
+
+	// Modules holds the names of the modules built in, or as dynamic modules, like
+	// "http_ssl_module", and those of "http", "mail" and "stream" when they are.
+	Modules map[string]bool
+
+	// UnknownModules are the modules of the --with-*_module and --without-*_module
+	// options that aren't known, like those of NGINX Plus, sorted. Their source files, if
+	// any, are read whether or not they are built.
+	UnknownModules []string
+
+	// AddModules are the paths of the third-party modules added with --add-module and
+	// --add-dynamic-module.
+	AddModules []string
+
+	// Defines are the feature macros nginx was built with, sorted.
+	Defines []string
+
+	// Undefines are the feature macros of the modules and options nginx wasn't built
+	// with, sorted. Other macros, like the NGX_HAVE_* macros configure sets for the
+	// platform, are neither.
+	Undefines []string
+}
+
+// ParseNginxV reads the output of `nginx -V`, or just the configure arguments, and
+// works out which modules nginx was built with. Options that don't select modules, like
+// --prefix, are ignored. The modules of unknown --with-*_module and --without-*_module
+// options are in UnknownModules.
+func ParseNginxV(output string) (*Build, error) {
+	b := &Build{Modules: map[string]bool{}}
+	argsLine := output
+	found := false
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if v, ok := cutPrefix(line, "nginx version:"); ok {
+			b.Version = strings.TrimSpace(v)
+		}
+		if a, ok := cutPrefix(line, "configure arguments:"); ok {
+			argsLine, found = a, true
+		}
+	}
+	if !found && b.Version != "" {
+		return nil, errors.New("no configure arguments in nginx -V output")
+	}
+
+	args, err := splitShellWords(argsLine)
+	if err != nil {
+		return nil, err
+	}
+	b.Args = args
+
+	for name, m := range configureModules {
+		if m.on {
+			b.Modules[name] = true
+		}
+	}
+	features := map[string]bool{}
+	for name, f := range configureFeatures {
+		features[name] = f.on
+	}
+	unknown := map[string]bool{}
+
+	for _, arg := range args {
+		opt, value, _ := strings.Cut(arg, "=")
+		switch opt {
+		case "--add-module", "--add-dynamic-module":
+			b.AddModules = append(b.AddModules, value)
+			continue
+		}
+
+		var name string
+		var on bool
+		if n, ok := cutPrefix(opt, "--with-"); ok {
+			name, on = n, true
+		} else if n, ok := cutPrefix(opt, "--without-"); ok {
+			name = n
+		} else {
+			continue
+		}
+
+		// --with-pcre=DIR, --with-openssl=DIR and the like name sources, not modules,
+		// but --with-stream=dynamic is a module
+		if value != "" && value != "dynamic" {
+			if _, ok := configureFeatures[name]; ok {
+				features[name] = true
+			}
+			continue
+		}
+		if _, ok := configureModules[name]; ok {
+			b.Modules[name] = on
+		} else if _, ok := configureFeatures[name]; ok {
+			features[name] = on
+		} else if strings.HasSuffix(name, "_module") {
+			unknown[name] = true
+		}
+	}
+
+	// the modules of a part that isn't built are not either
+	for name := range b.Modules {
+		if m := configureModules[name]; m.parent != "" && !b.Modules[m.parent] {
+			b.Modules[name] = false
+		}
+	}
+
+	defines := map[string]bool{}
+	for name, m := range configureModules {
+		for _, d := range m.defines {
+			defines[d] = defines[d] || b.Modules[name]
+		}
+		if !b.Modules[name] {
+			delete(b.Modules, name)
+		}
+	}
+	for name, f := range configureFeatures {
+		for _, d := range f.defines {
+			defines[d] = defines[d] || features[name]
+		}
+	}
+	for d, on := range defines {
+		if on {
+			b.Defines = append(b.Defines, d)
+		} else {
+			b.Undefines = append(b.Undefines, d)
+		}
+	}
+	for name := range unknown {
+		b.UnknownModules = append(b.UnknownModules, name)
+	}
+	sort.Strings(b.Defines)
+	sort.Strings(b.Undefines)
+	sort.Strings(b.UnknownModules)
+	return b, nil
+}
+
+// cutPrefix is strings.CutPrefix, which needs go 1.20.
+func cutPrefix(s string, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+// splitShellWords splits a command line into words like a POSIX shell, with quotes and
+// backslashes, as nginx -V prints the configure arguments.
+func splitShellWords(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' {
+				escaped = true
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inWord = true, true
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote in configure arguments")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// includesFile reports whether the nginx source file at path, relative to the src
+// directory or the root of the source tree, is built with b.
+func (b *Build) includesFile(path string) bool {
+	path = "/" + filepath.ToSlash(path)
+	base := filepath.Base(path)
+	for name, m := range configureModules {
+		if b.Modules[name] {
+			continue
+		}
+		files := m.files
+		if len(files) == 0 && len(m.dirs) == 0 {
+			files = []string{"ngx_" + name + ".c"}
+		}
+		for _, f := range files {
+			if base == f {
+				return false
+			}
+		}
+		for _, dir := range m.dirs {
+			if strings.Contains(path, "/"+dir+"/") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// addModulePaths returns the directories of the modules added with --add-module and
+// --add-dynamic-module. A module that isn't found at its path is looked up by the name
+// of its directory in modulesDir, if set.
+func (b *Build) addModulePaths(modulesDir string) ([]string, error) {
+	paths := make([]string, 0, len(b.AddModules))
+	for _, p := range b.AddModules {
+		if _, err := os.Stat(p); err == nil {
+			paths = append(paths, p)
+			continue
+		}
+		if modulesDir != "" {
+			alt := filepath.Join(modulesDir, filepath.Base(filepath.Clean(p)))
+			if _, err := os.Stat(alt); err == nil {
+				paths = append(paths, alt)
+				continue
+			}
+		}
+		return nil, fmt.Errorf("source of added module %s not found, set the modules directory to look it up by name", p)
+	}
+	return paths, nil
+}
@@ -0,0 +1,109 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNginxV(t *testing.T) {
+	t.Parallel()
+	build, err := ParseNginxV(testNginxV)
+	require.NoError(t, err)
+	require.Equal(t, "nginx/1.25.3", build.Version)
+	require.Contains(t, build.Args, "--with-cc-opt=-g -O2 -fstack-protector-strong")
+	require.Equal(t, []string{"/build/ngx_my_module"}, build.AddModules)
+	require.True(t, build.Modules["http_ssl_module"])
+	require.True(t, build.Modules["stream"])
+	require.True(t, build.Modules["stream_map_module"])
+	require.False(t, build.Modules["http_charset_module"])
+	require.False(t, build.Modules["mail"])
+	require.False(t, build.Modules["mail_smtp_module"])
+	require.Equal(t, []string{
+		"NGX_HTTP_CACHE", "NGX_HTTP_GZIP", "NGX_HTTP_SSL", "NGX_HTTP_UPSTREAM_ZONE", "NGX_HTTP_V2",
+		"NGX_HTTP_X_FORWARDED_FOR", "NGX_OPENSSL", "NGX_PCRE", "NGX_SSL", "NGX_STREAM",
+		"NGX_STREAM_UPSTREAM_ZONE", "NGX_THREADS",
+	}, build.Defines)
+	require.Contains(t, build.Undefines, "NGX_HTTP_DAV")
+	require.Contains(t, build.Undefines, "NGX_MAIL")
+	require.NotContains(t, build.Undefines, "NGX_SSL")
+	require.NotContains(t, build.Undefines, "NGX_HAVE_OPENAT")
+
+	require.True(t, build.includesFile("src/http/modules/ngx_http_ssl_module.c"))
+	require.True(t, build.includesFile("src/http/ngx_http_core_module.c"))
+	require.False(t, build.includesFile("src/http/modules/ngx_http_charset_filter_module.c"))
+	require.False(t, build.includesFile("src/http/v3/ngx_http_v3_module.c"))
+	require.False(t, build.includesFile("src/mail/ngx_mail_core_module.c"))
+
+	// just the configure arguments, without http and with a pcre source directory
+	build, err = ParseNginxV(`--without-http --with-pcre=../pcre2-10.42 --with-mail --without-mail_pop3_module`)
+	require.NoError(t, err)
+	require.Empty(t, build.Version)
+	require.False(t, build.Modules["http_proxy_module"])
+	require.True(t, build.Modules["mail_imap_module"])
+	require.False(t, build.Modules["mail_pop3_module"])
+	require.Equal(t, []string{"NGX_MAIL", "NGX_PCRE"}, build.Defines)
+	require.False(t, build.includesFile("src/http/ngx_http_core_module.c"))
+	require.False(t, build.includesFile("src/mail/ngx_mail_pop3_module.c"))
+	require.True(t, build.includesFile("src/mail/ngx_mail_imap_module.c"))
+}
+
+func TestParseNginxVErrors(t *testing.T) {
+	t.Parallel()
+	_, err := ParseNginxV("nginx version: nginx/1.25.3\nbuilt by gcc 12.2.0\n")
+	require.EqualError(t, err, "no configure arguments in nginx -V output")
+
+	_, err = ParseNginxV("--with-cc-opt='-O2")
+	require.EqualError(t, err, "unterminated quote in configure arguments")
+}
+
+func TestParseNginxVDistro(t *testing.T) {
+	t.Parallel()
+	// the nginx -V output of the Fedora package, which has modules without directives
+	build, err := ParseNginxV(`nginx version: nginx/1.24.0
+built by gcc 13.2.1 20230918 (Red Hat 13.2.1-3) (GCC)
+built with OpenSSL 3.1.1 30 May 2023
+TLS SNI support enabled
+configure arguments: --prefix=/usr/share/nginx --sbin-path=/usr/sbin/nginx --modules-path=/usr/lib64/nginx/modules ` +
+		`--conf-path=/etc/nginx/nginx.conf --error-log-path=/var/log/nginx/error.log --http-log-path=/var/log/nginx/access.log ` +
+		`--http-client-body-temp-path=/var/lib/nginx/tmp/client_body --http-proxy-temp-path=/var/lib/nginx/tmp/proxy ` +
+		`--http-fastcgi-temp-path=/var/lib/nginx/tmp/fastcgi --http-uwsgi-temp-path=/var/lib/nginx/tmp/uwsgi ` +
+		`--http-scgi-temp-path=/var/lib/nginx/tmp/scgi --pid-path=/run/nginx.pid --lock-path=/run/lock/subsys/nginx ` +
+		`--user=nginx --group=nginx --with-compat --with-debug --with-file-aio --with-google_perftools_module ` +
+		`--with-http_addition_module --with-http_auth_request_module --with-http_dav_module --with-http_degradation_module ` +
+		`--with-http_flv_module --with-http_gunzip_module --with-http_gzip_static_module --with-http_image_filter_module=dynamic ` +
+		`--with-http_mp4_module --with-http_perl_module=dynamic --with-http_random_index_module --with-http_realip_module ` +
+		`--with-http_secure_link_module --with-http_slice_module --with-http_ssl_module --with-http_stub_status_module ` +
+		`--with-http_sub_module --with-http_v2_module --with-http_xslt_module=dynamic --with-mail=dynamic --with-mail_ssl_module ` +
+		`--with-pcre --with-pcre-jit --with-stream=dynamic --with-stream_realip_module --with-stream_ssl_module ` +
+		`--with-stream_ssl_preread_module --with-threads --with-cc-opt='-O2 -flto=auto -ffat-lto-objects -fexceptions -g ` +
+		`-grecord-gcc-switches -pipe -Wall' --with-ld-opt='-Wl,-z,relro -Wl,--as-needed -Wl,-z,now -Wl,-E'
+`)
+	require.NoError(t, err)
+	require.Equal(t, "nginx/1.24.0", build.Version)
+	require.Empty(t, build.UnknownModules)
+	require.True(t, build.Modules["google_perftools_module"])
+	require.True(t, build.Modules["mail_ssl_module"])
+	require.True(t, build.includesFile("src/misc/ngx_google_perftools_module.c"))
+	require.False(t, build.includesFile("src/misc/ngx_cpp_test_module.cpp"))
+
+	// event methods and the test module have no directives
+	build, err = ParseNginxV("--with-poll_module --without-select_module --with-cpp_test_module")
+	require.NoError(t, err)
+	require.Empty(t, build.UnknownModules)
+	require.True(t, build.Modules["poll_module"])
+	require.False(t, build.Modules["select_module"])
+
+	// the modules of NGINX Plus are unknown, but don't fail the build
+	build, err = ParseNginxV("--with-http_auth_jwt_module --with-stream_mqtt_preread_module --with-http_f4f_module")
+	require.NoError(t, err)
+	require.Equal(t, []string{"http_auth_jwt_module", "http_f4f_module", "stream_mqtt_preread_module"}, build.UnknownModules)
+	require.True(t, build.includesFile("src/http/modules/ngx_http_auth_jwt_module.c"))
+}
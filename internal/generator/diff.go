@@ -0,0 +1,187 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DirectiveChange is a directive that differs between two source trees. OldMasks is
+// empty for an added directive, and NewMasks for a removed one.
+type DirectiveChange struct {
+	Name     string `json:"name"`
+	OldMasks []Mask `json:"oldMasks,omitempty"`
+	NewMasks []Mask `json:"newMasks,omitempty"`
+}
+
+// DirectiveDiff holds the directives added, removed and with changed masks in a source
+// tree compared to another, sorted by name.
+type DirectiveDiff struct {
+	Added   []DirectiveChange `json:"added"`
+	Removed []DirectiveChange `json:"removed"`
+	Changed []DirectiveChange `json:"changed"`
+}
+
+// Empty returns whether the two source trees have the same directives.
+func (d *DirectiveDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff extracts the directives from the source code in oldPath and in newPath like
+// Generate does with config, and returns how they differ. The order of the masks of a
+// directive, and of the variable names in a mask, doesn't matter.
+func Diff(oldPath string, newPath string, config GenerateConfig) (*DirectiveDiff, error) {
+	oldDirectives, err := directivesFromSrcCode(oldPath, config)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", oldPath, err)
+	}
+	newDirectives, err := directivesFromSrcCode(newPath, config)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", newPath, err)
+	}
+	return diffMasks(oldDirectives.masks, newDirectives.masks), nil
+}
+
+func diffMasks(oldMasks map[string][]Mask, newMasks map[string][]Mask) *DirectiveDiff {
+	d := &DirectiveDiff{
+		Added:   []DirectiveChange{},
+		Removed: []DirectiveChange{},
+		Changed: []DirectiveChange{},
+	}
+
+	for name, masks := range newMasks {
+		old, found := oldMasks[name]
+		switch {
+		case !found:
+			d.Added = append(d.Added, DirectiveChange{Name: name, NewMasks: masks})
+		case !sameMasks(old, masks):
+			d.Changed = append(d.Changed, DirectiveChange{Name: name, OldMasks: old, NewMasks: masks})
+		}
+	}
+	for name, masks := range oldMasks {
+		if _, found := newMasks[name]; !found {
+			d.Removed = append(d.Removed, DirectiveChange{Name: name, OldMasks: masks})
+		}
+	}
+
+	for _, changes := range [][]DirectiveChange{d.Added, d.Removed, d.Changed} {
+		changes := changes
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	}
+	return d
+}
+
+// maskKey returns a string that is the same for masks with the same variable names.
+func maskKey(m Mask) string {
+	names := append([]string{}, m...)
+	sort.Strings(names)
+	return strings.Join(names, "|")
+}
+
+func maskKeys(masks []Mask) []string {
+	keys := make([]string, 0, len(masks))
+	for _, m := range masks {
+		keys = append(keys, maskKey(m))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sameMasks(a []Mask, b []Mask) bool {
+	ka, kb := maskKeys(a), maskKeys(b)
+	if len(ka) != len(kb) {
+		return false
+	}
+	for i := range ka {
+		if ka[i] != kb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// missingMasks returns the masks of a that b doesn't have.
+func missingMasks(a []Mask, b []Mask) []Mask {
+	count := make(map[string]int, len(b))
+	for _, m := range b {
+		count[maskKey(m)]++
+	}
+	var missing []Mask
+	for _, m := range a {
+		k := maskKey(m)
+		if count[k] > 0 {
+			count[k]--
+			continue
+		}
+		missing = append(missing, m)
+	}
+	return missing
+}
+
+// WriteText writes d in a readable form, with a line per directive added or removed, and
+// the masks removed and added of the changed directives:
+//
+//	added:
+//	  + my_directive ngxHTTPMainConf|ngxConfTake1
+//	removed:
+//	  - old_directive ngxHTTPLocConf|ngxConfFlag
+//	changed:
+//	  ~ other_directive
+//	      - ngxHTTPMainConf|ngxConfTake1
+//	      + ngxHTTPMainConf|ngxConfTake12
+//	1 added, 1 removed, 1 changed
+func (d *DirectiveDiff) WriteText(w io.Writer) error {
+	var sb strings.Builder
+	joinMasks := func(masks []Mask) string {
+		strs := make([]string, 0, len(masks))
+		for _, m := range masks {
+			strs = append(strs, strings.Join(m, "|"))
+		}
+		return strings.Join(strs, ",")
+	}
+
+	if len(d.Added) > 0 {
+		sb.WriteString("added:\n")
+		for _, c := range d.Added {
+			fmt.Fprintf(&sb, "  + %s %s\n", c.Name, joinMasks(c.NewMasks))
+		}
+	}
+	if len(d.Removed) > 0 {
+		sb.WriteString("removed:\n")
+		for _, c := range d.Removed {
+			fmt.Fprintf(&sb, "  - %s %s\n", c.Name, joinMasks(c.OldMasks))
+		}
+	}
+	if len(d.Changed) > 0 {
+		sb.WriteString("changed:\n")
+		for _, c := range d.Changed {
+			fmt.Fprintf(&sb, "  ~ %s\n", c.Name)
+			for _, m := range missingMasks(c.OldMasks, c.NewMasks) {
+				fmt.Fprintf(&sb, "      - %s\n", strings.Join(m, "|"))
+			}
+			for _, m := range missingMasks(c.NewMasks, c.OldMasks) {
+				fmt.Fprintf(&sb, "      + %s\n", strings.Join(m, "|"))
+			}
+		}
+	}
+	fmt.Fprintf(&sb, "%d added, %d removed, %d changed\n", len(d.Added), len(d.Removed), len(d.Changed))
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteJSON writes d in JSON.
+func (d *DirectiveDiff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
@@ -0,0 +1,87 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package generator
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	oldPath, err := getTestSrcCodePath("diff/old")
+	require.NoError(t, err)
+	newPath, err := getTestSrcCodePath("diff/new")
+	require.NoError(t, err)
+
+	d, err := Diff(oldPath, newPath, GenerateConfig{Filter: Filters{"my_filtered": {}}})
+	require.NoError(t, err)
+	require.False(t, d.Empty())
+
+	for relativePath, write := range map[string]func(*bytes.Buffer) error{
+		"diffText": func(buf *bytes.Buffer) error { return d.WriteText(buf) },
+		"diffJSON": func(buf *bytes.Buffer) error { return d.WriteJSON(buf) },
+	} {
+		var buf bytes.Buffer
+		require.NoError(t, write(&buf))
+
+		expectedFilePath, err := getExpectedFilePath(relativePath)
+		require.NoError(t, err)
+		if *update {
+			require.NoError(t, os.WriteFile(expectedFilePath, buf.Bytes(), 0o644))
+			continue
+		}
+		expected, err := os.ReadFile(expectedFilePath)
+		require.NoError(t, err)
+		require.Equal(t, string(expected), buf.String(), relativePath)
+	}
+}
+
+func TestDiffSame(t *testing.T) {
+	t.Parallel()
+	path, err := getTestSrcCodePath("diff/new")
+	require.NoError(t, err)
+
+	d, err := Diff(path, path, GenerateConfig{})
+	require.NoError(t, err)
+	require.True(t, d.Empty())
+
+	var buf bytes.Buffer
+	require.NoError(t, d.WriteText(&buf))
+	require.Equal(t, "0 added, 0 removed, 0 changed\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, d.WriteJSON(&buf))
+	require.JSONEq(t, `{"added": [], "removed": [], "changed": []}`, buf.String())
+}
+
+func TestDiffOverride(t *testing.T) {
+	t.Parallel()
+	oldPath, err := getTestSrcCodePath("diff/old")
+	require.NoError(t, err)
+	newPath, err := getTestSrcCodePath("diff/new")
+	require.NoError(t, err)
+
+	d, err := Diff(oldPath, newPath, GenerateConfig{
+		Override: map[string][]Mask{"my_changed": {{"ngxHTTPMainConf", "ngxConfTake1"}}},
+	})
+	require.NoError(t, err)
+	require.Empty(t, d.Changed)
+}
+
+func TestDiffMissingPath(t *testing.T) {
+	t.Parallel()
+	path, err := getTestSrcCodePath("diff/new")
+	require.NoError(t, err)
+
+	_, err = Diff("testdata/missing", path, GenerateConfig{})
+	require.ErrorContains(t, err, "testdata/missing: ")
+}
@@ -60,12 +60,64 @@ type GenerateConfig struct {
 	// in the generated MatchFunc. Generally it should start with MatchFuncName.
 	// If it is empty, no comments will appear above the generated MatchFunc.
 	MatchFuncComment string `json:"matchFuncComment"`
+
+	// RegistryFuncName is the name assigned to the generated function that returns the
+	// directives as a crossplane.Registry. If it is empty, no such function is generated.
+	RegistryFuncName string `json:"registryFuncName"`
+
+	// ArgTypesMapName is the name assigned to the generated map variable with the
+	// crossplane.ArgType of each mask of the directives, as found in the set function,
+	// offset and post handler of their ngx_command_t. If it is empty, no such variable is
+	// generated. Arg types are always written in OutputJSON.
+	ArgTypesMapName string `json:"argTypesMapName"`
+
+	// Module is the module name set in the DirectiveSpecs of the generated Registry,
+	// like "njs".
+	Module string `json:"module"`
+
+	// Defines are the feature macros, like "NGX_HTTP_SSL" or "NGX_HTTP_V3", that nginx was
+	// configured with, as "NAME" or "NAME=VALUE", and Undefines the names of those it
+	// wasn't. The directives that #if, #ifdef and #ifndef exclude with them are not
+	// generated. A conditional with other macros, like nginx_version or NGX_HAVE_OPENAT,
+	// can't be evaluated and keeps its directives. If both are nil, conditionals are
+	// ignored and every directive is generated.
+	Defines   []string `json:"defines"`
+	Undefines []string `json:"undefines"`
+
+	// NginxV is the output of `nginx -V`, or just the configure arguments, of an nginx
+	// binary. If it is set, the source path is the nginx source tree the binary was
+	// built from, and only the directives of the modules it was built with are generated,
+	// along with those of the modules added with --add-module and --add-dynamic-module.
+	// The feature macros of the build, like NGX_HTTP_SSL, are added to Defines, and those
+	// of the modules it wasn't built with to Undefines.
+	NginxV string `json:"nginxV"`
+
+	// ModulesDir is the directory in which the modules added with --add-module and
+	// --add-dynamic-module are looked up by the name of their directory, when they are
+	// not found at the path they were built from.
+	ModulesDir string `json:"modulesDir"`
+
+	// OutputFormat is the format of the output, OutputGo or OutputJSON. If it is empty,
+	// Go source is generated.
+	OutputFormat string `json:"outputFormat"`
 }
 
+const (
+	// OutputGo generates a Go source file with the directive masks map and MatchFunc.
+	OutputGo = "go"
+
+	// OutputJSON generates a crossplane.SpecFile in JSON, which can be loaded at runtime
+	// with crossplane.LoadRegistry. DirectiveMapName, MatchFuncName, MatchFuncComment
+	// and RegistryFuncName are not used.
+	OutputJSON = "json"
+)
+
 // Generate receives a string sourcePath, an io.Writer writer, and a
 // GenerateConfig config. It will extract all the directives definitions
-// from the .c and .cpp files in sourcePath and its subdirectories,
-// then output the corresponding directive masks map and matchFunc via writer.
+// from the .c and .cpp files in sourcePath and its subdirectories, or those of the
+// modules an nginx binary was built with if config.NginxV is set,
+// then output the corresponding directive masks map and matchFunc via writer, or a
+// directive spec file if config.OutputFormat is OutputJSON.
 func Generate(sourcePath string, writer io.Writer, config GenerateConfig) error {
 	return genFromSrcCode(sourcePath, writer, config)
 }
@@ -14,10 +14,13 @@ import (
 	"html/template"
 	"io"
 	"io/fs"
+	"log"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	crossplane "github.com/nginxinc/nginx-go-crossplane"
 )
 
 // A Mask is a list of string, includes several variable names,
@@ -31,6 +34,8 @@ type supportFileTmplStruct struct {
 	MapVariableName string
 	MatchFnName     string
 	MatchFnComment  string
+	RegistryFnName  string
+	Module          string
 }
 
 var (
@@ -99,21 +104,58 @@ var ngxVarNameToGo = map[string]string{
 	"NGX_CONF_TAKE7":       "ngxConfTake7",
 }
 
-//nolint:nonamedreturns
-func masksFromFile(path string) (directive2Masks map[string][]Mask, err error) {
-	directive2Masks = make(map[string][]Mask, 0)
+// sourceDirectives holds the directives extracted from source files.
+type sourceDirectives struct {
+	masks map[string][]Mask
+
+	// argTypes holds the ArgType of each mask of a directive, in the same order.
+	argTypes map[string][]crossplane.ArgType
+
+	// tables holds the values of the ngx_conf_enum_t and ngx_conf_bitmask_t arrays, and
+	// bounds the ngx_conf_num_bounds_t, by variable name.
+	tables map[string][]string
+	bounds map[string][]int64
+
+	// duplicateHandlers holds the functions that reject a directive with "is duplicate".
+	duplicateHandlers map[string]bool
+}
+
+func newSourceDirectives() *sourceDirectives {
+	return &sourceDirectives{
+		masks:    make(map[string][]Mask, 0),
+		argTypes: make(map[string][]crossplane.ArgType, 0),
+		tables:   make(map[string][]string, 0),
+		bounds:   make(map[string][]int64, 0),
+
+		duplicateHandlers: make(map[string]bool, 0),
+	}
+}
+
+func (s *sourceDirectives) addFile(path string, macros *macros) error {
 	byteContent, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	strContent := string(byteContent)
 
 	// Remove comments
 	strContent = singleLineCommentExtracter.ReplaceAllString(strContent, "")
 	strContent = multiLineCommentExtracter.ReplaceAllString(strContent, "")
+
+	// Remove the code excluded by preprocessor conditionals
+	if macros != nil {
+		strContent, err = preprocess(strContent, macros)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
 	strContent = strings.ReplaceAll(strContent, "\r\n", "")
 	strContent = strings.ReplaceAll(strContent, "\n", "")
 
+	s.addValueTables(strContent)
+	s.addDuplicateHandlers(strContent)
+
 	// Extract directives definition code blocks, each code block contains a list of directives definition
 	blocks := directivesDefineBlockExtracter.FindAllStringSubmatch(strContent, -1)
 
@@ -121,9 +163,10 @@ func masksFromFile(path string) (directive2Masks map[string][]Mask, err error) {
 		// Extract directives and their attributes in the code block, the first dimension of subBlocks
 		// is index of directive, the second dimension is index of attributes
 		subBlocks := singleDirectiveExtracter.FindAllStringSubmatch(block[2], -1)
+		argTypes := commandArgTypes(block[2], len(subBlocks))
 
 		// Iterate through every directive
-		for _, attributes := range subBlocks {
+		for i, attributes := range subBlocks {
 			// Extract attributes from the directive
 			directiveName := strings.TrimSpace(attributes[1])
 			directiveMask := strings.Split(attributes[2], "|")
@@ -132,22 +175,24 @@ func masksFromFile(path string) (directive2Masks map[string][]Mask, err error) {
 			for idx, ngxVarName := range directiveMask {
 				goVarName, found := ngxVarNameToGo[strings.TrimSpace(ngxVarName)]
 				if !found {
-					return nil, fmt.Errorf("parsing directive %s, bitmask %s in source code not found in crossplane", directiveName, ngxVarName)
+					return fmt.Errorf("parsing directive %s, bitmask %s in source code not found in crossplane", directiveName, ngxVarName)
 				}
 				directiveMask[idx] = goVarName
 			}
 
-			directive2Masks[directiveName] = append(directive2Masks[directiveName], directiveMask)
+			s.masks[directiveName] = append(s.masks[directiveName], directiveMask)
+			s.argTypes[directiveName] = append(s.argTypes[directiveName], argTypes[i])
 		}
 	}
-	return directive2Masks, nil
+	return nil
 }
 
-//nolint:nonamedreturns
-func getMasksFromPath(path string) (directive2Masks map[string][]Mask, err error) {
-	directive2Masks = make(map[string][]Mask, 0)
-
-	err = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+// addPath adds the directives defined in the C/C++ files at path, and in its
+// subdirectories. If include is not nil, only the files for which it returns true,
+// given their path relative to path, are read.
+func (s *sourceDirectives) addPath(path string, macros *macros, include func(string) bool) error {
+	root := path
+	return filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -162,62 +207,137 @@ func getMasksFromPath(path string) (directive2Masks map[string][]Mask, err error
 			return nil
 		}
 
-		directive2MasksInFile, err := masksFromFile(path)
-		if err != nil {
-			return err
+		if include != nil {
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			if !include(rel) {
+				return nil
+			}
 		}
 
-		for directive, masksInFile := range directive2MasksInFile {
-			directive2Masks[directive] = append(directive2Masks[directive], masksInFile...)
+		return s.addFile(path, macros)
+	})
+}
+
+//nolint:nonamedreturns
+func getDirectivesFromPath(path string, config GenerateConfig) (directives *sourceDirectives, err error) {
+	directives = newSourceDirectives()
+
+	var build *Build
+	var include func(string) bool
+	if config.NginxV != "" {
+		if build, err = ParseNginxV(config.NginxV); err != nil {
+			return nil, err
 		}
+		for _, m := range build.UnknownModules {
+			log.Printf("warning: unknown module %s in the configure arguments, its source files are read whether or not it is built", m)
+		}
+		include = build.includesFile
+	}
 
-		return nil
-	})
+	var macros *macros
+	if config.Defines != nil || config.Undefines != nil || build != nil {
+		var defs, undefs []string
+		if build != nil {
+			defs = append(defs, build.Defines...)
+			undefs = append(undefs, build.Undefines...)
+		}
+		// the defines of the config come last to override those of the build
+		if macros, err = newMacros(append(defs, config.Defines...), append(undefs, config.Undefines...)); err != nil {
+			return nil, err
+		}
+	}
 
-	if err != nil {
+	if err = directives.addPath(path, macros, include); err != nil {
 		return nil, err
 	}
 
-	if len(directive2Masks) == 0 {
+	if build != nil {
+		modulePaths, err := build.addModulePaths(config.ModulesDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range modulePaths {
+			if err = directives.addPath(p, macros, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(directives.masks) == 0 {
 		return nil, errors.New("can't find any directives in the directory and subdirectories, please check the path")
 	}
 
-	return directive2Masks, nil
+	directives.resolveArgTypes()
+	directives.markUnique()
+	return directives, nil
 }
 
-func genFromSrcCode(codePath string, writer io.Writer, config GenerateConfig) error {
-	directive2Masks, err := getMasksFromPath(codePath)
+// directivesFromSrcCode returns the directives in codePath, with config.Filter and
+// config.Override applied.
+func directivesFromSrcCode(codePath string, config GenerateConfig) (*sourceDirectives, error) {
+	directives, err := getDirectivesFromPath(codePath, config)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	directive2Masks := directives.masks
 
 	filter := config.Filter
 	if len(filter) > 0 {
 		for d := range directive2Masks {
 			if _, found := filter[d]; found {
 				delete(directive2Masks, d)
+				delete(directives.argTypes, d)
 			}
 		}
 	}
 
+	// the arg types of overridden directives are unknown
 	override := config.Override
 	if override != nil {
 		for d := range directive2Masks {
 			if newMasks, found := override[d]; found {
 				directive2Masks[d] = newMasks
+				delete(directives.argTypes, d)
 			}
 		}
 	}
 
+	return directives, nil
+}
+
+func genFromSrcCode(codePath string, writer io.Writer, config GenerateConfig) error {
+	directives, err := directivesFromSrcCode(codePath, config)
+	if err != nil {
+		return err
+	}
+	directive2Masks := directives.masks
+
+	switch config.OutputFormat {
+	case "", OutputGo:
+	case OutputJSON:
+		return writeSpecFile(writer, directive2Masks, directives.argTypes, config.Module)
+	default:
+		return fmt.Errorf("unknown output format %q", config.OutputFormat)
+	}
+
 	err = supportFileTmpl.Execute(writer, supportFileTmplStruct{
 		Directive2Masks: directive2Masks,
 		MapVariableName: config.DirectiveMapName,
 		MatchFnName:     config.MatchFuncName,
 		MatchFnComment:  config.MatchFuncComment,
+		RegistryFnName:  config.RegistryFuncName,
+		Module:          config.Module,
 	})
 	if err != nil {
 		return err
 	}
 
+	if config.ArgTypesMapName != "" {
+		return writeArgTypes(writer, config.ArgTypesMapName, directives.argTypes)
+	}
+
 	return nil
 }
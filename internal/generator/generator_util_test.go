@@ -19,6 +19,7 @@ import (
 	"strings"
 	"testing"
 
+	crossplane "github.com/nginxinc/nginx-go-crossplane"
 	"github.com/stretchr/testify/require"
 )
 
@@ -69,6 +70,15 @@ func getExpectedFilePath(relativePath string) (string, error) {
 	return path.Join(root, "internal", "generator", "testdata", "expected", relativePath), nil
 }
 
+const testNginxV = `nginx version: nginx/1.25.3
+built by gcc 12.2.0 (Debian 12.2.0-14)
+built with OpenSSL 3.0.11 19 Sep 2023
+TLS SNI support enabled
+configure arguments: --prefix=/etc/nginx --with-cc-opt='-g -O2 -fstack-protector-strong' ` +
+	`--with-http_ssl_module --with-http_v2_module --without-http_charset_module --with-stream=dynamic ` +
+	`--with-threads --add-dynamic-module=/build/ngx_my_module
+`
+
 //nolint:funlen,gocognit
 func TestGenFromSrcCode(t *testing.T) {
 	t.Parallel()
@@ -198,6 +208,93 @@ func TestGenFromSrcCode(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		"outputJSON_pass": {
+			relativePath: "specFile",
+			config: GenerateConfig{
+				Module:       "my_module",
+				OutputFormat: OutputJSON,
+				Override: map[string][]Mask{
+					"my_directive_1": {
+						Mask{"ngxHTTPMainConf", "ngxHTTPSrvConf", "ngxConfBlock", "ngxConfExpr", "ngxConf1More"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		"unknownOutputFormat_fail": {
+			relativePath: "normalDefinition",
+			config: GenerateConfig{
+				OutputFormat: "toml",
+			},
+			wantErr: true,
+		},
+		"conditionals_pass": {
+			relativePath: "conditionals",
+			config: GenerateConfig{
+				DirectiveMapName: "directives",
+				MatchFuncName:    "Match",
+				Defines:          []string{"NGX_HTTP_SSL", "NGX_PCRE=1", "NGX_WIN32=0", "NGX_THREADS"},
+				Undefines:        []string{"NGX_HTTP_V3", "NGX_COMPAT"},
+			},
+			wantErr: false,
+		},
+		// Without defines, conditionals are ignored and all directives are kept
+		"conditionalsIgnored_pass": {
+			relativePath: "conditionalsIgnored",
+			config: GenerateConfig{
+				DirectiveMapName: "directives",
+				MatchFuncName:    "Match",
+			},
+			wantErr: false,
+		},
+		"invalidDefine_fail": {
+			relativePath: "conditionals",
+			config: GenerateConfig{
+				DirectiveMapName: "directives",
+				MatchFuncName:    "Match",
+				Defines:          []string{"NGX HTTP"},
+			},
+			wantErr: true,
+		},
+		// Only the modules of the build and the added ones are generated
+		"nginxV_pass": {
+			relativePath: "nginxSource",
+			config: GenerateConfig{
+				DirectiveMapName: "directives",
+				MatchFuncName:    "Match",
+				NginxV:           testNginxV,
+				ModulesDir:       "testdata/source_codes/nginxModules",
+			},
+			wantErr: false,
+		},
+		"nginxVAddedModuleNotFound_fail": {
+			relativePath: "nginxSource",
+			config: GenerateConfig{
+				DirectiveMapName: "directives",
+				MatchFuncName:    "Match",
+				NginxV:           testNginxV,
+			},
+			wantErr: true,
+		},
+		"argTypes_pass": {
+			relativePath: "argTypes",
+			config: GenerateConfig{
+				DirectiveMapName: "directives",
+				MatchFuncName:    "Match",
+				ArgTypesMapName:  "argTypes",
+			},
+			wantErr: false,
+		},
+		"withRegistryFunc_pass": {
+			relativePath: "withRegistryFunc",
+			config: GenerateConfig{
+				DirectiveMapName: "myDirectives",
+				MatchFuncName:    "MyMatchFn",
+				RegistryFuncName: "MyRegistry",
+				Module:           "my_module",
+			},
+			wantErr: false,
+		},
 	}
 	for name, tc := range tests {
 		tc := tc
@@ -225,6 +322,11 @@ func TestGenFromSrcCode(t *testing.T) {
 				return
 			}
 
+			if tc.config.OutputFormat == OutputJSON {
+				_, err = crossplane.LoadRegistry(bytes.NewReader(buf.Bytes()))
+				require.NoError(t, err)
+			}
+
 			expectedFilePth, err := getExpectedFilePath(tc.relativePath)
 			if err != nil {
 				t.Fatal(err)
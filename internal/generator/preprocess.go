@@ -0,0 +1,410 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Defines is a set of feature macros, like NGX_HTTP_SSL, and their values.
+type Defines map[string]string
+
+// parseDefines parses macros given as "NAME" or "NAME=VALUE". A macro without value is 1,
+// like with the -D option of a C compiler.
+func parseDefines(defs []string) (Defines, error) {
+	defines := make(Defines, len(defs))
+	for _, def := range defs {
+		name, value, found := strings.Cut(def, "=")
+		name = strings.TrimSpace(name)
+		if !identifierRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid macro name %q", name)
+		}
+		if !found {
+			value = "1"
+		}
+		defines[name] = strings.TrimSpace(value)
+	}
+	return defines, nil
+}
+
+// macros are what the preprocessor knows of the macros: those that are defined, with
+// their values, and those that are not. Any other macro is unknown.
+type macros struct {
+	defines   Defines
+	undefined map[string]struct{}
+}
+
+// newMacros returns the macros with the given defines, as "NAME" or "NAME=VALUE", and
+// the names of the undefined macros. A macro that is both is defined.
+func newMacros(defines []string, undefines []string) (*macros, error) {
+	m := &macros{undefined: make(map[string]struct{}, len(undefines))}
+	var err error
+	if m.defines, err = parseDefines(defines); err != nil {
+		return nil, err
+	}
+	for _, name := range undefines {
+		name = strings.TrimSpace(name)
+		if !identifierRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid macro name %q", name)
+		}
+		if _, ok := m.defines[name]; !ok {
+			m.undefined[name] = struct{}{}
+		}
+	}
+	return m, nil
+}
+
+// isDefined reports whether a macro is defined, and whether that is known.
+func (m *macros) isDefined(name string) (defined bool, known bool) {
+	if _, ok := m.defines[name]; ok {
+		return true, true
+	}
+	_, ok := m.undefined[name]
+	return false, ok
+}
+
+//nolint:gochecknoglobals
+var (
+	identifierRegexp = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+
+	// a preprocessor directive, like "#  if (NGX_PCRE)"
+	ppDirectiveRegexp = regexp.MustCompile(`^\s*#\s*(\w+)\s*(.*)$`)
+)
+
+type ppCond struct {
+	// active is whether the lines of the current branch are kept
+	active bool
+	// taken is whether a branch of the conditional was known to be true already
+	taken bool
+	// parentActive is whether the lines around the conditional are kept
+	parentActive bool
+	seenElse     bool
+}
+
+// preprocess blanks the lines of src that #if, #ifdef, #ifndef, #elif and #else exclude
+// with the macros, and the conditional directives themselves. Line numbers are kept. Other
+// preprocessor directives, like #define and #include, are ignored. Comments should be
+// removed from src before.
+//
+// A condition that depends on unknown macros, like NGX_HAVE_* which configure sets for
+// the platform, can't be evaluated, so its branch is kept, and so are the branches after
+// it that aren't known to be false.
+func preprocess(src string, m *macros) (string, error) {
+	lines := strings.Split(src, "\n")
+	var stack []ppCond
+	active := true
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		start := i
+		// join the continuation lines of a directive
+		for strings.HasSuffix(lines[i], "\\") && i+1 < len(lines) && ppDirectiveRegexp.MatchString(line) {
+			line = strings.TrimSuffix(line, "\\") + " " + lines[i+1]
+			lines[i] = ""
+			i++
+		}
+
+		match := ppDirectiveRegexp.FindStringSubmatch(line)
+		if match == nil {
+			if !active {
+				lines[i] = ""
+			}
+			continue
+		}
+		directive, expr := match[1], strings.TrimSpace(match[2])
+		lineErr := func(err error) error {
+			return fmt.Errorf("line %d: #%s %s: %w", start+1, directive, expr, err)
+		}
+
+		switch directive {
+		case "if", "ifdef", "ifndef":
+			cond := ppCond{parentActive: active}
+			if active {
+				ok, known, err := evalCondition(directive, expr, m)
+				if err != nil {
+					return "", lineErr(err)
+				}
+				cond.active, cond.taken = ok || !known, ok && known
+			}
+			stack = append(stack, cond)
+		case "elif", "else":
+			if len(stack) == 0 {
+				return "", lineErr(errors.New("no matching #if"))
+			}
+			cond := &stack[len(stack)-1]
+			if cond.seenElse {
+				return "", lineErr(errors.New("after #else"))
+			}
+			cond.active = false
+			if cond.parentActive && !cond.taken {
+				ok, known := true, true
+				if directive == "elif" {
+					var err error
+					if ok, known, err = evalCondition("if", expr, m); err != nil {
+						return "", lineErr(err)
+					}
+				}
+				cond.active, cond.taken = ok || !known, ok && known
+			}
+			cond.seenElse = directive == "else"
+		case "endif":
+			if len(stack) == 0 {
+				return "", lineErr(errors.New("no matching #if"))
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if !active {
+				lines[i] = ""
+			}
+			continue
+		}
+
+		lines[i] = ""
+		active = len(stack) == 0 || stack[len(stack)-1].active
+	}
+
+	if len(stack) > 0 {
+		return "", errors.New("missing #endif")
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// evalCondition returns the value of the condition of a conditional directive, and
+// whether it is known.
+func evalCondition(directive string, expr string, m *macros) (bool, bool, error) {
+	switch directive {
+	case "ifdef", "ifndef":
+		if !identifierRegexp.MatchString(expr) {
+			return false, false, errors.New("invalid macro name")
+		}
+		defined, known := m.isDefined(expr)
+		return defined == (directive == "ifdef"), known, nil
+	}
+
+	tokens, err := tokenizeExpr(expr)
+	if err != nil {
+		return false, false, err
+	}
+	p := &exprParser{tokens: tokens, macros: m}
+	v, err := p.parseOr()
+	if err != nil {
+		return false, false, err
+	}
+	if p.pos < len(p.tokens) {
+		return false, false, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return v.n != 0, v.known, nil
+}
+
+//nolint:gochecknoglobals
+var exprTokenRegexp = regexp.MustCompile(`^(\s+|[A-Za-z_]\w*|0[xX][0-9a-fA-F]+[uUlL]*|\d+[uUlL]*|&&|\|\||==|!=|<=|>=|[()!<>])`)
+
+func tokenizeExpr(expr string) ([]string, error) {
+	var tokens []string
+	for expr != "" {
+		tok := exprTokenRegexp.FindString(expr)
+		if tok == "" {
+			return nil, fmt.Errorf("unexpected %q", expr)
+		}
+		expr = expr[len(tok):]
+		if strings.TrimSpace(tok) != "" {
+			tokens = append(tokens, tok)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("empty expression")
+	}
+	return tokens, nil
+}
+
+// exprValue is the value of an expression, which isn't known if it depends on unknown
+// macros.
+type exprValue struct {
+	n     int64
+	known bool
+}
+
+func knownValue(n int64) exprValue {
+	return exprValue{n: n, known: true}
+}
+
+func boolValue(b bool) exprValue {
+	if b {
+		return knownValue(1)
+	}
+	return knownValue(0)
+}
+
+// exprParser evaluates the expressions of #if: integers, macros, defined, !, the
+// comparison operators, && and ||. Undefined macros are 0, and the value of unknown
+// macros isn't known. && and || are known if their known operand decides them.
+type exprParser struct {
+	tokens []string
+	pos    int
+	macros *macros
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *exprParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *exprParser) parseOr() (exprValue, error) {
+	v, err := p.parseAnd()
+	for err == nil && p.peek() == "||" {
+		p.next()
+		var r exprValue
+		if r, err = p.parseAnd(); err != nil {
+			break
+		}
+		switch {
+		case (v.known && v.n != 0) || (r.known && r.n != 0):
+			v = knownValue(1)
+		case v.known && r.known:
+			v = knownValue(0)
+		default:
+			v = exprValue{}
+		}
+	}
+	return v, err
+}
+
+func (p *exprParser) parseAnd() (exprValue, error) {
+	v, err := p.parseComparison()
+	for err == nil && p.peek() == "&&" {
+		p.next()
+		var r exprValue
+		if r, err = p.parseComparison(); err != nil {
+			break
+		}
+		switch {
+		case (v.known && v.n == 0) || (r.known && r.n == 0):
+			v = knownValue(0)
+		case v.known && r.known:
+			v = knownValue(1)
+		default:
+			v = exprValue{}
+		}
+	}
+	return v, err
+}
+
+func (p *exprParser) parseComparison() (exprValue, error) {
+	v, err := p.parseUnary()
+	for err == nil {
+		op := p.peek()
+		switch op {
+		case "==", "!=", "<", ">", "<=", ">=":
+		default:
+			return v, nil
+		}
+		p.next()
+		var r exprValue
+		if r, err = p.parseUnary(); err != nil {
+			break
+		}
+		if !v.known || !r.known {
+			v = exprValue{}
+			continue
+		}
+		switch op {
+		case "==":
+			v = boolValue(v.n == r.n)
+		case "!=":
+			v = boolValue(v.n != r.n)
+		case "<":
+			v = boolValue(v.n < r.n)
+		case ">":
+			v = boolValue(v.n > r.n)
+		case "<=":
+			v = boolValue(v.n <= r.n)
+		case ">=":
+			v = boolValue(v.n >= r.n)
+		}
+	}
+	return v, err
+}
+
+func (p *exprParser) parseUnary() (exprValue, error) {
+	tok := p.next()
+	switch {
+	case tok == "":
+		return exprValue{}, errors.New("unexpected end of expression")
+	case tok == "!":
+		v, err := p.parseUnary()
+		if !v.known {
+			return v, err
+		}
+		return boolValue(v.n == 0), err
+	case tok == "(":
+		v, err := p.parseOr()
+		if err != nil {
+			return exprValue{}, err
+		}
+		if p.next() != ")" {
+			return exprValue{}, errors.New("missing )")
+		}
+		return v, nil
+	case tok == "defined":
+		name := p.next()
+		paren := name == "("
+		if paren {
+			name = p.next()
+		}
+		if !identifierRegexp.MatchString(name) {
+			return exprValue{}, errors.New("defined needs a macro name")
+		}
+		if paren && p.next() != ")" {
+			return exprValue{}, errors.New("missing )")
+		}
+		defined, known := p.macros.isDefined(name)
+		if !known {
+			return exprValue{}, nil
+		}
+		return boolValue(defined), nil
+	case identifierRegexp.MatchString(tok):
+		value, ok := p.macros.defines[tok]
+		if !ok {
+			if _, undefined := p.macros.undefined[tok]; undefined {
+				return knownValue(0), nil
+			}
+			return exprValue{}, nil
+		}
+		if value == "" {
+			return knownValue(0), nil
+		}
+		n, err := parseInteger(value)
+		if err != nil {
+			return exprValue{}, fmt.Errorf("macro %s is not an integer: %q", tok, value)
+		}
+		return knownValue(n), nil
+	default:
+		n, err := parseInteger(tok)
+		if err != nil {
+			return exprValue{}, fmt.Errorf("unexpected %q", tok)
+		}
+		return knownValue(n), nil
+	}
+}
+
+// parseInteger parses a C integer literal, in decimal, octal or hexadecimal.
+func parseInteger(s string) (int64, error) {
+	return strconv.ParseInt(strings.TrimRight(s, "uUlL"), 0, 64)
+}
@@ -0,0 +1,126 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestPreprocess(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		src       string
+		defines   []string
+		undefines []string
+		want      []string
+		wantErr   string
+	}{
+		"ifdefAndIfndef": {
+			src:       "#ifdef A\na\n#endif\n#ifndef A\nnot a\n#endif\n#ifdef B\nb\n#endif",
+			defines:   []string{"A"},
+			undefines: []string{"B"},
+			want:      []string{"a"},
+		},
+		"elifChain": {
+			src:       "#if (A)\na\n#elif (B >= 2)\nb\n#elif (C)\nc\n#else\nelse\n#endif",
+			defines:   []string{"B=2", "C"},
+			undefines: []string{"A"},
+			want:      []string{"b"},
+		},
+		"else": {
+			src:       "#if A || (B && C)\na\n#else\nelse\n#endif",
+			undefines: []string{"A", "B", "C"},
+			want:      []string{"else"},
+		},
+		"unknownMacroKeepsBranches": {
+			src:     "#if (NGX_HAVE_OPENAT)\na\n#elif (B)\nb\n#elif (C)\nc\n#else\nelse\n#endif",
+			defines: []string{"B"},
+			want:    []string{"a", "b"},
+		},
+		"unknownVersion": {
+			src:     "#if (nginx_version >= 1023000)\nnew\n#else\nold\n#endif",
+			defines: []string{"NGX_HTTP_SSL"},
+			want:    []string{"new", "old"},
+		},
+		"unknownDecidedByKnown": {
+			src:       "#if (A && U)\nand\n#endif\n#if (B || U)\nor\n#endif\n#if (B && U)\nunknown\n#endif\n#if defined(U)\ndefined\n#endif",
+			defines:   []string{"B"},
+			undefines: []string{"A"},
+			want:      []string{"or", "unknown", "defined"},
+		},
+		"hexLiteral": {
+			src:     "#if (V >= 0x010017)\nnew\n#endif\n#if (V < 0X10000UL)\nold\n#endif",
+			defines: []string{"V=0x010019"},
+			want:    []string{"new"},
+		},
+		"nestedInExcluded": {
+			src:     "#if 0\n#if (A)\na\n#else\nelse\n#endif\n#endif\nafter",
+			defines: []string{"A"},
+			want:    []string{"after"},
+		},
+		"continuationLine": {
+			src:     "#if (A \\\n    && B)\nab\n#endif",
+			defines: []string{"A", "B"},
+			want:    []string{"ab"},
+		},
+		"defineAndIncludeIgnored": {
+			src:       "#include <ngx_config.h>\n#define X 1\n#  if X\nx\n#  endif",
+			undefines: []string{"X"},
+			want:      []string{"#include <ngx_config.h>", "#define X 1"},
+		},
+		"missingEndif": {
+			src:     "#if A\na",
+			wantErr: "missing #endif",
+		},
+		"unmatchedEndif": {
+			src:     "a\n#endif",
+			wantErr: "line 2: #endif : no matching #if",
+		},
+		"elseAfterElse": {
+			src:     "#if A\n#else\n#else\n#endif",
+			wantErr: "line 3: #else : after #else",
+		},
+		"invalidExpression": {
+			src:     "#if (A +)\n#endif",
+			wantErr: `line 1: #if (A +): unexpected "+)"`,
+		},
+		"nonIntegerMacro": {
+			src:     "#if A\n#endif",
+			defines: []string{"A=yes"},
+			wantErr: `line 1: #if A: macro A is not an integer: "yes"`,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			macros, err := newMacros(tc.defines, tc.undefines)
+			require.NoError(t, err)
+
+			out, err := preprocess(tc.src, macros)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, strings.Count(tc.src, "\n"), strings.Count(out, "\n"), "line count changed")
+
+			var kept []string
+			for _, line := range strings.Split(out, "\n") {
+				if line != "" {
+					kept = append(kept, line)
+				}
+			}
+			require.Equal(t, tc.want, kept)
+		})
+	}
+}
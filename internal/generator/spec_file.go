@@ -0,0 +1,65 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	crossplane "github.com/nginxinc/nginx-go-crossplane"
+)
+
+const (
+	goNameBlock  = "ngxConfBlock"
+	goNameUnique = "ngxConfUnique"
+)
+
+// writeSpecFile writes the masks of the directives, along with their arg types if known,
+// as a crossplane.SpecFile in JSON.
+func writeSpecFile(writer io.Writer, directive2Masks map[string][]Mask, directive2ArgTypes map[string][]crossplane.ArgType, module string) error {
+	names := make([]string, 0, len(directive2Masks))
+	for name := range directive2Masks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	file := crossplane.SpecFile{Module: module, Directives: []crossplane.SpecFileEntry{}}
+	for _, name := range names {
+		argTypes := directive2ArgTypes[name]
+		for i, mask := range directive2Masks[name] {
+			entry := crossplane.SpecFileEntry{Name: name, Contexts: []string{}, Args: []string{}}
+			if i < len(argTypes) {
+				t := argTypes[i]
+				entry.ArgType = &t
+			}
+			for _, goName := range mask {
+				specName, context, ok := crossplane.SpecFileMaskName(goName)
+				switch {
+				case ok && context:
+					entry.Contexts = append(entry.Contexts, specName)
+				case ok:
+					entry.Args = append(entry.Args, specName)
+				case goName == goNameBlock:
+					entry.Block = true
+				case goName == goNameUnique:
+					entry.Unique = true
+				default:
+					return fmt.Errorf("directive %s has bitmask %s that can't be written to a spec file", name, goName)
+				}
+			}
+			file.Directives = append(file.Directives, entry)
+		}
+	}
+
+	enc := json.NewEncoder(writer)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(file)
+}
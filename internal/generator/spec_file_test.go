@@ -0,0 +1,41 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package generator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	crossplane "github.com/nginxinc/nginx-go-crossplane"
+)
+
+func TestSpecFileUnique(t *testing.T) {
+	t.Parallel()
+	codePath, err := getTestSrcCodePath("argTypes")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, genFromSrcCode(codePath, &buf, GenerateConfig{OutputFormat: OutputJSON}))
+	registry, err := crossplane.LoadRegistry(&buf)
+	require.NoError(t, err)
+
+	// the directives set by a function that rejects duplicates are unique
+	for name, unique := range map[string]bool{
+		"my_flag":          true,
+		"my_comp_level":    true,
+		"my_root":          true,
+		"my_next_upstream": false,
+		"my_zone":          false,
+	} {
+		specs, ok := registry.Lookup(name)
+		require.True(t, ok, name)
+		require.Equal(t, unique, specs[0].Unique, name)
+	}
+}
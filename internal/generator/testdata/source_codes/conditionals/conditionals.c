@@ -0,0 +1,59 @@
+static ngx_command_t my_directives[] = {
+
+    { ngx_string("my_directive"),
+      NGX_HTTP_MAIN_CONF|NGX_CONF_TAKE1,
+      0,
+      0,
+      0,
+      NULL },
+
+#if (NGX_HTTP_SSL)
+
+    { ngx_string("my_ssl_directive"),
+      NGX_HTTP_MAIN_CONF|NGX_HTTP_SRV_CONF|NGX_CONF_FLAG,
+      0,
+      0,
+      0,
+      NULL },
+
+#  if (NGX_HTTP_V3)
+    { ngx_string("my_quic_directive"),
+      NGX_HTTP_SRV_CONF|NGX_CONF_FLAG,
+      0,
+      0,
+      0,
+      NULL },
+#  endif
+
+#endif
+
+#if (NGX_PCRE && !NGX_WIN32)
+    { ngx_string("my_regex_directive"),
+      NGX_HTTP_LOC_CONF|NGX_CONF_TAKE12,
+      0,
+      0,
+      0,
+      NULL },
+#elif defined(NGX_THREADS)
+    { ngx_string("my_thread_directive"),
+      NGX_HTTP_LOC_CONF|NGX_CONF_TAKE1,
+      0,
+      0,
+      0,
+      NULL },
+#else
+    { ngx_string("my_fallback_directive"),
+      NGX_HTTP_LOC_CONF|NGX_CONF_TAKE1,
+      0,
+      0,
+      0,
+      NULL },
+#endif
+
+#ifndef NGX_COMPAT
+    /* #if 0 in a comment is not a conditional */
+    { ngx_string("my_compat_directive"), NGX_HTTP_MAIN_CONF|NGX_CONF_NOARGS, 0, 0, 0, NULL },
+#endif
+
+    ngx_null_command
+};
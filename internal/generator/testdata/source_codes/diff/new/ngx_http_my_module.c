@@ -0,0 +1,25 @@
+static ngx_command_t  ngx_http_my_commands[] = {
+
+    { ngx_string("my_kept"),
+      NGX_HTTP_SRV_CONF|NGX_HTTP_MAIN_CONF|NGX_CONF_FLAG,
+      ngx_conf_set_flag_slot,
+      NGX_HTTP_SRV_CONF_OFFSET,
+      offsetof(ngx_http_my_srv_conf_t, kept),
+      NULL },
+
+    { ngx_string("my_changed"),
+      NGX_HTTP_MAIN_CONF|NGX_CONF_TAKE12,
+      ngx_http_my_changed,
+      0,
+      0,
+      NULL },
+
+    { ngx_string("my_added"),
+      NGX_HTTP_MAIN_CONF|NGX_HTTP_SRV_CONF|NGX_HTTP_LOC_CONF|NGX_CONF_TAKE1,
+      ngx_conf_set_msec_slot,
+      NGX_HTTP_LOC_CONF_OFFSET,
+      offsetof(ngx_http_my_loc_conf_t, added),
+      NULL },
+
+      ngx_null_command
+};
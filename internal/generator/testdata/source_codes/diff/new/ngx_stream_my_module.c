@@ -0,0 +1,11 @@
+static ngx_command_t  ngx_stream_my_commands[] = {
+
+    { ngx_string("my_changed"),
+      NGX_STREAM_MAIN_CONF|NGX_CONF_TAKE1,
+      ngx_stream_my_changed,
+      0,
+      0,
+      NULL },
+
+      ngx_null_command
+};
@@ -0,0 +1,11 @@
+static ngx_command_t  ngx_http_my_module_commands[] = {
+
+    { ngx_string("my_directive"),
+      NGX_HTTP_LOC_CONF|NGX_CONF_TAKE1,
+      0,
+      0,
+      0,
+      NULL },
+
+      ngx_null_command
+};
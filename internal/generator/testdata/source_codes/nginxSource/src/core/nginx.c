@@ -0,0 +1,11 @@
+static ngx_command_t  nginx_commands[] = {
+
+    { ngx_string("worker_processes"),
+      NGX_MAIN_CONF|NGX_DIRECT_CONF|NGX_CONF_TAKE1,
+      0,
+      0,
+      0,
+      NULL },
+
+      ngx_null_command
+};
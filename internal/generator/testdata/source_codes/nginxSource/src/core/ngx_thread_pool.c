@@ -0,0 +1,13 @@
+static ngx_command_t  ngx_thread_pool_commands[] = {
+
+#if (NGX_THREADS)
+    { ngx_string("thread_pool"),
+      NGX_MAIN_CONF|NGX_DIRECT_CONF|NGX_CONF_TAKE23,
+      0,
+      0,
+      0,
+      NULL },
+#endif
+
+      ngx_null_command
+};
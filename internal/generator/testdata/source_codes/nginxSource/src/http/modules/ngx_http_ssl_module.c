@@ -0,0 +1,11 @@
+static ngx_command_t  ngx_http_ssl_module_commands[] = {
+
+    { ngx_string("ssl_certificate"),
+      NGX_HTTP_MAIN_CONF|NGX_HTTP_SRV_CONF|NGX_CONF_TAKE1,
+      0,
+      0,
+      0,
+      NULL },
+
+      ngx_null_command
+};
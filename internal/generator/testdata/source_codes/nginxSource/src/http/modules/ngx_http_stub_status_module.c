@@ -0,0 +1,11 @@
+static ngx_command_t  ngx_http_stub_status_module_commands[] = {
+
+    { ngx_string("stub_status"),
+      NGX_HTTP_SRV_CONF|NGX_HTTP_LOC_CONF|NGX_CONF_NOARGS|NGX_CONF_TAKE1,
+      0,
+      0,
+      0,
+      NULL },
+
+      ngx_null_command
+};
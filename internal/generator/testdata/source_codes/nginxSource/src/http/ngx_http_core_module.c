@@ -0,0 +1,44 @@
+static ngx_command_t  ngx_http_core_module_commands[] = {
+
+    { ngx_string("listen"),
+      NGX_HTTP_SRV_CONF|NGX_CONF_1MORE,
+      0,
+      0,
+      0,
+      NULL },
+
+#if (NGX_HTTP_GZIP)
+
+    { ngx_string("gzip_vary"),
+      NGX_HTTP_MAIN_CONF|NGX_HTTP_SRV_CONF|NGX_HTTP_LOC_CONF|NGX_CONF_FLAG,
+      0,
+      0,
+      0,
+      NULL },
+
+#endif
+
+#if (NGX_HTTP_DAV)
+
+    { ngx_string("create_full_put_path"),
+      NGX_HTTP_MAIN_CONF|NGX_HTTP_SRV_CONF|NGX_HTTP_LOC_CONF|NGX_CONF_FLAG,
+      0,
+      0,
+      0,
+      NULL },
+
+#endif
+
+#if (NGX_HAVE_OPENAT)
+
+    { ngx_string("disable_symlinks"),
+      NGX_HTTP_MAIN_CONF|NGX_HTTP_SRV_CONF|NGX_HTTP_LOC_CONF|NGX_CONF_TAKE12,
+      0,
+      0,
+      0,
+      NULL },
+
+#endif
+
+      ngx_null_command
+};
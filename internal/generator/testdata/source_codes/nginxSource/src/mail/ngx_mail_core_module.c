@@ -0,0 +1,11 @@
+static ngx_command_t  ngx_mail_core_module_commands[] = {
+
+    { ngx_string("protocol"),
+      NGX_MAIL_SRV_CONF|NGX_CONF_TAKE1,
+      0,
+      0,
+      0,
+      NULL },
+
+      ngx_null_command
+};
@@ -0,0 +1,11 @@
+static ngx_command_t  ngx_stream_ssl_preread_module_commands[] = {
+
+    { ngx_string("ssl_preread"),
+      NGX_STREAM_MAIN_CONF|NGX_STREAM_SRV_CONF|NGX_CONF_FLAG,
+      0,
+      0,
+      0,
+      NULL },
+
+      ngx_null_command
+};
@@ -0,0 +1,15 @@
+static ngx_command_t my_directives[] = {
+
+    { ngx_string("all_bitmask"),
+      NGX_CONF_NOARGS|NGX_CONF_TAKE1|
+      NGX_CONF_TAKE2|NGX_CONF_TAKE3|NGX_CONF_TAKE4|NGX_CONF_TAKE5|NGX_CONF_TAKE6|NGX_CONF_TAKE7|
+      NGX_CONF_TAKE12|NGX_CONF_TAKE13|NGX_CONF_TAKE23|NGX_CONF_TAKE123|NGX_CONF_TAKE1234|
+      NGX_CONF_BLOCK|NGX_CONF_FLAG|NGX_CONF_ANY|NGX_CONF_1MORE|
+      NGX_CONF_2MORE|NGX_DIRECT_CONF|NGX_MAIN_CONF
+      ,
+      0,
+      0,
+      NULL }, 
+
+    ngx_null_command
+};
\ No newline at end of file
@@ -0,0 +1,594 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RewriteRule changes the uses of a directive that don't work, or are deprecated, in a
+// version of a product to an equivalent that does.
+type RewriteRule struct {
+	Name      string
+	Directive string
+
+	// Product and Version are the product and the first version that need the rewrite.
+	// The rule is applied when migrating to Version or newer. Rules of ProductOSS are
+	// applied to NGINX Plus too, with the nginx version that the NGINX Plus release is
+	// based on.
+	Product Product
+	Version string
+
+	// Rewrite is called with every use of Directive. It may change stmt and the other
+	// directives of ctx.Block, and returns the directives to put in place of stmt, or nil
+	// to keep stmt. Every change must be recorded with ctx.Report.
+	Rewrite func(stmt *Directive, ctx *RewriteContext) Directives
+}
+
+// RewriteContext is where a RewriteRule is applied.
+type RewriteContext struct {
+	File     string
+	BlockCtx []string
+
+	// Block holds the directives of the block stmt is in, stmt included. Directives
+	// of included files are in the Block of their file.
+	Block Directives
+
+	rule    *RewriteRule
+	changes *[]MigrationChange
+
+	// the payload and the Config of Block, to follow includes
+	payload       *Payload
+	cfg           int
+	blockContexts []BlockContext
+	// files holds the files of the directives found in included files
+	files map[*Directive]string
+}
+
+// blockSite is a directive of a block, or of a file included into it, and its Config.
+type blockSite struct {
+	stmt *Directive
+	cfg  int
+}
+
+// resolve returns the directives of block, a block of the Config cfg in the context ctx,
+// with the directives of the files its include directives include in their place. It
+// also reports whether every include was resolved, which isn't the case when the
+// payload was parsed with SingleFile.
+func (c *RewriteContext) resolve(block Directives, cfg int, ctx blockCtx) ([]blockSite, bool) {
+	var sites []blockSite
+	resolved := true
+	w := newPayloadWalker(c.payload, c.blockContexts, false)
+	w.open[cfg] = true
+	_ = w.walkBlock(cfg, cfg, &block, ctx, nil, func(s *walkSite) error {
+		if s.stmt.Directive == "include" && !s.stmt.IsInclude() {
+			resolved = false
+		}
+		if len(s.parents) > 0 {
+			return errSkipDirective
+		}
+		sites = append(sites, blockSite{stmt: s.stmt, cfg: s.cfg})
+		if s.cfg != cfg {
+			c.files[s.stmt] = s.file
+		}
+		return nil
+	})
+	return sites, resolved
+}
+
+// Report records a change to stmt in the MigrationReport.
+func (c *RewriteContext) Report(stmt *Directive, format string, a ...interface{}) {
+	file := c.File
+	if f, ok := c.files[stmt]; ok {
+		file = f
+	}
+	if stmt.File != "" {
+		file = stmt.File
+	}
+	*c.changes = append(*c.changes, MigrationChange{
+		File:        file,
+		Line:        stmt.Line,
+		Directive:   stmt.Directive,
+		BlockCtx:    append([]string{}, c.BlockCtx...),
+		Rule:        c.rule.Name,
+		Description: fmt.Sprintf(format, a...),
+	})
+}
+
+// MigrationChange is a change made by Migrate.
+type MigrationChange struct {
+	File        string
+	Line        int
+	Directive   string
+	BlockCtx    []string
+	Rule        string
+	Description string
+}
+
+// MigrationReport is the result of Migrate.
+type MigrationReport struct {
+	Product Product
+	Version string
+
+	// Changes holds every change, sorted by file and line.
+	Changes []MigrationChange
+}
+
+// MigrateOptions are the options of Migrate.
+type MigrateOptions struct {
+	// Product is the product to migrate to. It defaults to ProductOSS.
+	Product Product
+
+	// Rules adds rewrite rules, like those of third-party modules, to the ones of
+	// DefaultRewriteRules. They are applied after the default rules of the same Version.
+	Rules []RewriteRule
+
+	// SkipDefaultRules only applies Rules.
+	SkipDefaultRules bool
+
+	// BlockContexts are the custom block contexts of the payload, like those given to
+	// Parse in ParseOptions.BlockContexts.
+	BlockContexts []BlockContext
+}
+
+// the nginx versions that the NGINX Plus releases in productVersions are based on
+//
+//nolint:gochecknoglobals
+var plusBaseVersions = map[string]string{
+	"R30":         "1.25.1",
+	"R31":         "1.25.3",
+	versionLatest: versionLatest,
+}
+
+// Migrate returns a copy of the payload in which the directives that don't work, or are
+// deprecated, in the given version of options.Product are rewritten, along with a report
+// of the changes. The payload itself isn't changed. The version is a version like "1.25.1",
+// or "latest"; for NGINX Plus, one of Versions(ProductPlus).
+//
+// Migrate doesn't check the result, use RequiredVersion or Parse with the directives of
+// the version for that.
+func Migrate(payload *Payload, version string, options *MigrateOptions) (*Payload, *MigrationReport, error) {
+	if options == nil {
+		options = &MigrateOptions{}
+	}
+	product := options.Product
+	if product == "" {
+		product = ProductOSS
+	}
+
+	var rules []RewriteRule
+	if !options.SkipDefaultRules {
+		rules = append(rules, DefaultRewriteRules()...)
+	}
+	rules = append(rules, options.Rules...)
+
+	var applied []*RewriteRule
+	for i := range rules {
+		ok, err := ruleApplies(&rules[i], product, version)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			applied = append(applied, &rules[i])
+		}
+	}
+	sort.SliceStable(applied, func(i, j int) bool {
+		c, _ := compareVersions(applied[i].Version, applied[j].Version)
+		return c < 0
+	})
+
+	m := &migrator{
+		payload:       clonePayload(payload),
+		rules:         applied,
+		report:        &MigrationReport{Product: product, Version: version},
+		blockContexts: options.BlockContexts,
+	}
+	_ = newPayloadWalker(m.payload, options.BlockContexts, true).walk(m.migrate)
+
+	sort.SliceStable(m.report.Changes, func(i, j int) bool {
+		a, b := m.report.Changes[i], m.report.Changes[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return m.payload, m.report, nil
+}
+
+// ruleApplies reports whether migrating to version of product needs rule.
+func ruleApplies(rule *RewriteRule, product Product, version string) (bool, error) {
+	switch {
+	case rule.Product == product || rule.Product == "":
+	case rule.Product == ProductOSS && product == ProductPlus:
+		base, ok := plusBaseVersions[version]
+		if !ok {
+			return false, fmt.Errorf("unknown %s version %q", product, version)
+		}
+		version = base
+	default:
+		return false, nil
+	}
+	c, err := compareVersions(version, rule.Version)
+	if err != nil {
+		return false, fmt.Errorf("rewrite rule %q: %w", rule.Name, err)
+	}
+	return c >= 0, nil
+}
+
+// compareVersions compares versions like "1.25.1" or "R31", and "latest", which is newer
+// than all of them.
+func compareVersions(a, b string) (int, error) {
+	if a == b {
+		return 0, nil
+	}
+	if a == versionLatest {
+		return 1, nil
+	}
+	if b == versionLatest {
+		return -1, nil
+	}
+	pa, err := versionParts(a)
+	if err != nil {
+		return 0, err
+	}
+	pb, err := versionParts(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func versionParts(v string) ([]int, error) {
+	fields := strings.Split(strings.TrimPrefix(v, "R"), ".")
+	parts := make([]int, 0, len(fields))
+	for _, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version %q", v)
+		}
+		parts = append(parts, n)
+	}
+	return parts, nil
+}
+
+type migrator struct {
+	payload       *Payload
+	rules         []*RewriteRule
+	report        *MigrationReport
+	blockContexts []BlockContext
+}
+
+// migrate replaces a directive with what the first rule that rewrites it returns. The
+// directives the rule adds aren't rewritten again.
+func (m *migrator) migrate(site *walkSite) error {
+	replacement, ok := m.rewrite(site, *site.block)
+	if !ok {
+		return nil
+	}
+	block, i := site.block, site.index
+	rest := append(Directives{}, (*block)[i+1:]...)
+	*block = append(append((*block)[:i], replacement...), rest...)
+
+	k := indexDirective(replacement, site.stmt)
+	if k < 0 {
+		site.index = i + len(replacement) - 1
+		return errSkipDirective
+	}
+	site.index = i + k
+	site.added = len(replacement) - 1 - k
+	return nil
+}
+
+func (m *migrator) rewrite(site *walkSite, block Directives) (Directives, bool) {
+	stmt := site.stmt
+	for _, rule := range m.rules {
+		if rule.Directive != stmt.Directive {
+			continue
+		}
+		rc := &RewriteContext{
+			File:          site.file,
+			BlockCtx:      site.ctx,
+			Block:         block,
+			rule:          rule,
+			changes:       &m.report.Changes,
+			payload:       m.payload,
+			cfg:           site.cfg,
+			blockContexts: m.blockContexts,
+			files:         map[*Directive]string{},
+		}
+		if replacement := rule.Rewrite(stmt, rc); replacement != nil {
+			return replacement, true
+		}
+	}
+	return nil, false
+}
+
+func indexDirective(block Directives, stmt *Directive) int {
+	for i, d := range block {
+		if d == stmt {
+			return i
+		}
+	}
+	return -1
+}
+
+func clonePayload(p *Payload) *Payload {
+	c := *p
+	c.Errors = append([]PayloadError{}, p.Errors...)
+	c.Config = make([]Config, len(p.Config))
+	for i, config := range p.Config {
+		config.Errors = append([]ConfigError{}, config.Errors...)
+		config.Parsed = cloneDirectives(config.Parsed)
+		c.Config[i] = config
+	}
+	return &c
+}
+
+func cloneDirectives(block Directives) Directives {
+	if block == nil {
+		return nil
+	}
+	c := make(Directives, len(block))
+	for i, d := range block {
+		c[i] = cloneDirective(d)
+	}
+	return c
+}
+
+func cloneDirective(d *Directive) *Directive {
+	c := *d
+	c.Args = append([]string{}, d.Args...)
+	if d.Includes != nil {
+		c.Includes = append([]int{}, d.Includes...)
+	}
+	c.Block = cloneDirectives(d.Block)
+	if d.LeadingComments != nil {
+		c.LeadingComments = append([]string{}, d.LeadingComments...)
+	}
+	if d.ArgComments != nil {
+		c.ArgComments = append([]ArgComment{}, d.ArgComments...)
+	}
+	if d.ArgBreaks != nil {
+		c.ArgBreaks = append([]int{}, d.ArgBreaks...)
+	}
+	if d.LeadingCommentBlankLines != nil {
+		c.LeadingCommentBlankLines = append([]int{}, d.LeadingCommentBlankLines...)
+	}
+	return &c
+}
+
+// DefaultRewriteRules returns the rewrite rules of the changes of nginx that break or
+// deprecate existing configs.
+func DefaultRewriteRules() []RewriteRule {
+	return []RewriteRule{
+		{
+			Name:      "listen-spdy",
+			Directive: "listen",
+			Product:   ProductOSS,
+			Version:   "1.9.5",
+			Rewrite:   rewriteListenSpdy,
+		},
+		{
+			Name:      "listen-http2",
+			Directive: "listen",
+			Product:   ProductOSS,
+			Version:   "1.25.1",
+			Rewrite:   rewriteListenHTTP2,
+		},
+		{
+			Name:      "ssl-on",
+			Directive: "ssl",
+			Product:   ProductOSS,
+			Version:   "1.25.1",
+			Rewrite:   rewriteSSL,
+		},
+		renameRule("http2_idle_timeout", "keepalive_timeout", "1.19.7"),
+		renameRule("http2_max_requests", "keepalive_requests", "1.19.7"),
+		renameRule("http2_recv_timeout", "client_header_timeout", "1.19.7"),
+		removeRule("http2_push", "1.25.1", "HTTP/2 server push is no longer supported"),
+		removeRule("http2_push_preload", "1.25.1", "HTTP/2 server push is no longer supported"),
+	}
+}
+
+// renameRule returns a rule that renames a directive, or removes it if the block already
+// has the new one.
+func renameRule(from string, to string, version string) RewriteRule {
+	return RewriteRule{
+		Name:      from,
+		Directive: from,
+		Product:   ProductOSS,
+		Version:   version,
+		Rewrite: func(stmt *Directive, ctx *RewriteContext) Directives {
+			if findDirective(ctx.Block, to) != nil {
+				ctx.Report(stmt, `removed "%s", the block already has "%s"`, from, to)
+				return Directives{}
+			}
+			ctx.Report(stmt, `renamed "%s" to "%s"`, from, to)
+			stmt.Directive = to
+			return Directives{stmt}
+		},
+	}
+}
+
+// removeRule returns a rule that removes a directive that has no replacement.
+func removeRule(directive string, version string, reason string) RewriteRule {
+	return RewriteRule{
+		Name:      directive,
+		Directive: directive,
+		Product:   ProductOSS,
+		Version:   version,
+		Rewrite: func(stmt *Directive, ctx *RewriteContext) Directives {
+			ctx.Report(stmt, `removed "%s": %s`, directive, reason)
+			return Directives{}
+		},
+	}
+}
+
+func findDirective(block Directives, name string) *Directive {
+	for _, d := range block {
+		if d.Directive == name {
+			return d
+		}
+	}
+	return nil
+}
+
+func removeArg(stmt *Directive, arg string) bool {
+	for i, a := range stmt.Args {
+		if a == arg {
+			stmt.Args = append(stmt.Args[:i:i], stmt.Args[i+1:]...)
+			stmt.ArgBreaks = nil
+			stmt.ArgComments = nil
+			return true
+		}
+	}
+	return false
+}
+
+// rewriteListenSpdy replaces the spdy parameter of listen with http2, which the
+// listen-http2 rule rewrites further for newer versions.
+func rewriteListenSpdy(stmt *Directive, ctx *RewriteContext) Directives {
+	if blockCtx(ctx.BlockCtx).key() != "http>server" || !contains(stmt.Args, "spdy") {
+		return nil
+	}
+	removeArg(stmt, "spdy")
+	if !contains(stmt.Args, "http2") {
+		stmt.Args = append(stmt.Args, "http2")
+	}
+	ctx.Report(stmt, `replaced the "spdy" parameter with "http2"`)
+	return nil
+}
+
+// rewriteListenHTTP2 replaces the http2 parameter of listen with "http2 on;".
+func rewriteListenHTTP2(stmt *Directive, ctx *RewriteContext) Directives {
+	if blockCtx(ctx.BlockCtx).key() != "http>server" || !removeArg(stmt, "http2") {
+		return nil
+	}
+	ctx.Report(stmt, `removed the "http2" parameter`)
+	if findDirective(ctx.Block, "http2") != nil {
+		return Directives{stmt}
+	}
+	http2 := &Directive{Directive: "http2", Args: []string{"on"}, Line: stmt.Line, File: stmt.File}
+	ctx.Report(http2, `added "http2 on"`)
+	return Directives{stmt, http2}
+}
+
+// rewriteSSL replaces "ssl on;" with the ssl parameter on the listen directives it
+// applies to, and removes "ssl off;", which is the default. Listen directives in included
+// files count too. Servers with their own "ssl" directive are left to it. "ssl on;" is
+// kept if a server has includes that weren't resolved, or listen directives in included
+// files, which other servers may include too, so those are reported instead of changed.
+func rewriteSSL(stmt *Directive, ctx *RewriteContext) Directives {
+	type server struct {
+		block   *Directives
+		listens []*Directive
+		// included holds the listen directives that are in included files
+		included []*Directive
+	}
+	var servers []server
+	addServer := func(block *Directives, cfg int, serverCtx blockCtx, own bool) bool {
+		sites, resolved := ctx.resolve(*block, cfg, serverCtx)
+		srv := server{block: block}
+		for _, site := range sites {
+			switch {
+			case site.stmt.Directive == "ssl" && !own:
+				// the server overrides the ssl of the block around it
+				return resolved
+			case site.stmt.Directive == "listen" && len(site.stmt.Args) > 0:
+				srv.listens = append(srv.listens, site.stmt)
+				if site.cfg != cfg && !contains(site.stmt.Args, "ssl") {
+					srv.included = append(srv.included, site.stmt)
+				}
+			}
+		}
+		servers = append(servers, srv)
+		return resolved
+	}
+
+	resolved := true
+	switch blockCtx(ctx.BlockCtx).key() {
+	case "http>server", "mail>server":
+		resolved = addServer(&ctx.Block, ctx.cfg, ctx.BlockCtx, true)
+	case "http", "mail":
+		var sites []blockSite
+		sites, resolved = ctx.resolve(ctx.Block, ctx.cfg, ctx.BlockCtx)
+		serverCtx := append(append(blockCtx{}, ctx.BlockCtx...), "server")
+		for _, site := range sites {
+			if site.stmt.Directive == "server" && site.stmt.IsBlock() {
+				resolved = addServer(&site.stmt.Block, site.cfg, serverCtx, false) && resolved
+			}
+		}
+	default:
+		return nil
+	}
+	// the listen directives of the servers can't all be found, so ssl is kept
+	if !resolved {
+		return nil
+	}
+
+	if len(stmt.Args) != 1 || stmt.Args[0] != "on" {
+		ctx.Report(stmt, `removed "ssl %s"`, strings.Join(stmt.Args, " "))
+		return Directives{}
+	}
+
+	included := false
+	for _, srv := range servers {
+		for _, listen := range srv.included {
+			ctx.Report(listen, `needs the "ssl" parameter, which isn't added because the file is included`)
+			included = true
+		}
+	}
+	if included {
+		ctx.Report(stmt, `kept "ssl on", listen directives in included files need the "ssl" parameter`)
+		return nil
+	}
+
+	ctx.Report(stmt, `removed "ssl on"`)
+	var replacement Directives
+	for _, srv := range servers {
+		for _, listen := range srv.listens {
+			if !contains(listen.Args, "ssl") {
+				listen.Args = append(listen.Args, "ssl")
+				ctx.Report(listen, `added the "ssl" parameter`)
+			}
+		}
+		if len(srv.listens) > 0 || ctx.BlockCtx[0] != "http" {
+			continue
+		}
+		// a server with no listen directives listens on port 80
+		listen := &Directive{Directive: "listen", Args: []string{"80", "ssl"}, Line: stmt.Line, File: stmt.File}
+		ctx.Report(listen, `added "listen 80 ssl", the default listen with the "ssl" parameter`)
+		if srv.block == &ctx.Block {
+			replacement = append(replacement, listen)
+		} else {
+			*srv.block = append(Directives{listen}, *srv.block...)
+		}
+	}
+	if replacement == nil {
+		replacement = Directives{}
+	}
+	return replacement
+}
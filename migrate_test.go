@@ -0,0 +1,223 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const migrateConfig = `http {
+    http2_push_preload on;
+    server {
+        listen 443 ssl http2;
+        listen [::]:443 ssl http2;
+        server_name example.com;
+    }
+    server {
+        listen 8443 spdy;
+        ssl on;
+        http2_idle_timeout 3m;
+    }
+    server {
+        ssl on;
+    }
+}
+`
+
+func buildString(t *testing.T, payload *Payload) string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, Build(&buf, payload.Config[0], &BuildOptions{}))
+	return buf.String()
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+	payload := parseString(t, migrateConfig)
+
+	migrated, report, err := Migrate(payload, "1.25.1", nil)
+	require.NoError(t, err)
+	require.Equal(t, `http {
+    server {
+        listen 443 ssl;
+        http2 on;
+        listen [::]:443 ssl;
+        server_name example.com;
+    }
+    server {
+        listen 8443 ssl;
+        http2 on;
+        keepalive_timeout 3m;
+    }
+    server {
+        listen 80 ssl;
+    }
+}`, buildString(t, migrated))
+
+	var changes []string
+	for _, c := range report.Changes {
+		changes = append(changes, c.Rule+": "+c.Directive+": "+c.Description)
+		require.Equal(t, "nginx.conf", c.File)
+	}
+	require.Equal(t, []string{
+		`http2_push_preload: http2_push_preload: removed "http2_push_preload": HTTP/2 server push is no longer supported`,
+		`listen-http2: listen: removed the "http2" parameter`,
+		`listen-http2: http2: added "http2 on"`,
+		`listen-http2: listen: removed the "http2" parameter`,
+		`listen-spdy: listen: replaced the "spdy" parameter with "http2"`,
+		`listen-http2: listen: removed the "http2" parameter`,
+		`listen-http2: http2: added "http2 on"`,
+		`ssl-on: listen: added the "ssl" parameter`,
+		`ssl-on: ssl: removed "ssl on"`,
+		`http2_idle_timeout: http2_idle_timeout: renamed "http2_idle_timeout" to "keepalive_timeout"`,
+		`ssl-on: ssl: removed "ssl on"`,
+		`ssl-on: listen: added "listen 80 ssl", the default listen with the "ssl" parameter`,
+	}, changes)
+	require.Equal(t, 10, report.Changes[8].Line)
+	require.Equal(t, []string{"http", "server"}, report.Changes[8].BlockCtx)
+
+	// the payload itself is unchanged
+	require.Equal(t, migrateConfig[:len(migrateConfig)-1], buildString(t, payload))
+
+	// migrated again, nothing changes
+	again, report, err := Migrate(migrated, "1.25.1", nil)
+	require.NoError(t, err)
+	require.Empty(t, report.Changes)
+	require.Equal(t, buildString(t, migrated), buildString(t, again))
+}
+
+func TestMigrateSSLIncludes(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"nginx.conf":  "http {\n    server {\n        include listen.conf;\n        ssl on;\n    }\n}\n",
+		"listen.conf": "listen 443;\n",
+	}
+	options := &ParseOptions{
+		Open: func(path string) (io.ReadCloser, error) {
+			config, ok := files[filepath.Base(path)]
+			if !ok {
+				return nil, os.ErrNotExist
+			}
+			return io.NopCloser(strings.NewReader(config)), nil
+		},
+		SkipDirectiveContextCheck: true,
+		SkipDirectiveArgsCheck:    true,
+	}
+	payload, err := Parse("nginx.conf", options)
+	require.NoError(t, err)
+
+	// other servers may include the file too, so its listen is reported, not changed,
+	// and ssl is kept
+	migrated, report, err := Migrate(payload, "1.25.1", nil)
+	require.NoError(t, err)
+	require.Equal(t, strings.TrimSuffix(files["nginx.conf"], "\n"), buildString(t, migrated))
+	require.Equal(t, []string{"443"}, migrated.Config[1].Parsed[0].Args)
+	var changes []string
+	for _, c := range report.Changes {
+		changes = append(changes, c.File+": "+c.Directive+": "+c.Description)
+	}
+	require.Equal(t, []string{
+		`listen.conf: listen: needs the "ssl" parameter, which isn't added because the file is included`,
+		`nginx.conf: ssl: kept "ssl on", listen directives in included files need the "ssl" parameter`,
+	}, changes)
+
+	// without the included file, the listen directives are unknown so ssl is kept
+	options.SingleFile = true
+	payload, err = Parse("nginx.conf", options)
+	require.NoError(t, err)
+	migrated, report, err = Migrate(payload, "1.25.1", nil)
+	require.NoError(t, err)
+	require.Empty(t, report.Changes)
+	require.Equal(t, strings.TrimSuffix(files["nginx.conf"], "\n"), buildString(t, migrated))
+}
+
+func TestMigrateSSLOverride(t *testing.T) {
+	t.Parallel()
+	payload := parseString(t, "http {\n    ssl on;\n    server {\n        listen 443;\n    }\n    server {\n        ssl off;\n        listen 80;\n    }\n}\n")
+
+	// the server with its own ssl directive isn't changed by the one of http
+	migrated, report, err := Migrate(payload, "1.25.1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "http {\n    server {\n        listen 443 ssl;\n    }\n    server {\n        listen 80;\n    }\n}", buildString(t, migrated))
+	var changes []string
+	for _, c := range report.Changes {
+		changes = append(changes, c.Directive+": "+c.Description)
+	}
+	require.Equal(t, []string{
+		`ssl: removed "ssl on"`,
+		`listen: added the "ssl" parameter`,
+		`ssl: removed "ssl off"`,
+	}, changes)
+}
+
+func TestMigrateVersions(t *testing.T) {
+	t.Parallel()
+	payload := parseString(t, migrateConfig)
+
+	// only spdy and the HTTP/2 timeouts are older than 1.24
+	migrated, report, err := Migrate(payload, "1.24", nil)
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 2)
+	require.Contains(t, buildString(t, migrated), "listen 8443 http2;")
+	require.Contains(t, buildString(t, migrated), "ssl on;")
+
+	_, report, err = Migrate(payload, "1.9.0", nil)
+	require.NoError(t, err)
+	require.Empty(t, report.Changes)
+
+	// R31 is based on nginx 1.25.3
+	_, report, err = Migrate(payload, "R31", &MigrateOptions{Product: ProductPlus})
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 12)
+	_, report, err = Migrate(payload, "R30", &MigrateOptions{Product: ProductPlus, SkipDefaultRules: true})
+	require.NoError(t, err)
+	require.Empty(t, report.Changes)
+
+	_, _, err = Migrate(payload, "R12", &MigrateOptions{Product: ProductPlus})
+	require.EqualError(t, err, `unknown nginx-plus version "R12"`)
+	_, _, err = Migrate(payload, "1.x", nil)
+	require.EqualError(t, err, `rewrite rule "listen-spdy": invalid version "1.x"`)
+}
+
+func TestMigrateCustomRule(t *testing.T) {
+	t.Parallel()
+	payload := parseString(t, "http {\n    my_directive a b;\n    server {\n        my_directive c;\n    }\n}\n")
+
+	migrated, report, err := Migrate(payload, "latest", &MigrateOptions{
+		SkipDefaultRules: true,
+		Rules: []RewriteRule{{
+			Name:      "my-directive",
+			Directive: "my_directive",
+			Version:   "2.0",
+			Rewrite: func(stmt *Directive, ctx *RewriteContext) Directives {
+				if len(stmt.Args) == 1 {
+					return nil
+				}
+				var split Directives
+				for _, arg := range stmt.Args {
+					split = append(split, &Directive{Directive: "my_directive", Args: []string{arg}, Line: stmt.Line})
+				}
+				ctx.Report(stmt, "split the arguments")
+				return split
+			},
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "http {\n    my_directive a;\n    my_directive b;\n    server {\n        my_directive c;\n    }\n}", buildString(t, migrated))
+	require.Equal(t, []MigrationChange{{
+		File: "nginx.conf", Line: 2, Directive: "my_directive", BlockCtx: []string{"http"},
+		Rule: "my-directive", Description: "split the arguments",
+	}}, report.Changes)
+}
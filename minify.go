@@ -0,0 +1,104 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// MinifyOptions determine the behavior of Minify.
+type MinifyOptions struct {
+	// If true, every include directive is replaced by the directives of the configs it
+	// includes, like Payload.Combined does, so that the output holds the whole payload.
+	InlineIncludes bool
+
+	Builders []RegisterBuilder // handle specific directives
+}
+
+// Minify writes payload as the smallest config that parses to the same directives.
+// Comments are dropped, arguments are quoted only when needed and no whitespace is written
+// other than the spaces between a directive and its arguments.
+//
+// A payload of several configs can only be written as one with InlineIncludes, use
+// MinifyConfig to write its configs one by one instead.
+func Minify(w io.Writer, payload *Payload, options *MinifyOptions) error {
+	if options == nil {
+		options = &MinifyOptions{}
+	}
+	if len(payload.Config) == 0 {
+		return nil
+	}
+
+	if options.InlineIncludes {
+		combined, err := payload.Combined()
+		if err != nil {
+			return err
+		}
+		payload = combined
+	} else if len(payload.Config) > 1 {
+		return errors.New("payload has several configs, minify them with MinifyConfig or set InlineIncludes")
+	}
+
+	return MinifyConfig(w, payload.Config[0], options)
+}
+
+// MinifyConfig writes config like Minify does. Its includes are kept, InlineIncludes
+// is ignored.
+func MinifyConfig(w io.Writer, config Config, options *MinifyOptions) error {
+	if options == nil {
+		options = &MinifyOptions{}
+	}
+
+	buildOptions := &BuildOptions{Builders: options.Builders}
+	for _, o := range buildOptions.Builders {
+		o.applyBuildOptions(buildOptions)
+	}
+
+	var sb strings.Builder
+	minifyBlock(&sb, config.Parsed, buildOptions)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func minifyBlock(sb *strings.Builder, block Directives, options *BuildOptions) {
+	for _, stmt := range block {
+		if stmt.IsComment() {
+			continue
+		}
+
+		directive := Enquote(stmt.Directive)
+		if ext, ok := options.extBuilders[directive]; ok {
+			_, _ = sb.WriteString(ext.Build(stmt))
+			continue
+		}
+
+		_, _ = sb.WriteString(directive)
+		if directive == "if" {
+			_, _ = sb.WriteString(" (")
+		}
+		for i, arg := range stmt.Args {
+			if i > 0 || directive != "if" {
+				_, _ = sb.WriteString(" ")
+			}
+			_, _ = sb.WriteString(Enquote(arg))
+		}
+		if directive == "if" {
+			_, _ = sb.WriteString(")")
+		}
+
+		if !stmt.IsBlock() {
+			_, _ = sb.WriteString(";")
+			continue
+		}
+		_, _ = sb.WriteString("{")
+		minifyBlock(sb, stmt.Block, options)
+		_, _ = sb.WriteString("}")
+	}
+}
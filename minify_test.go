@@ -0,0 +1,134 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinify(t *testing.T) {
+	t.Parallel()
+	payload, err := Parse(getTestConfigPath("with-comments", "nginx.conf"), &ParseOptions{ParseComments: true})
+	require.NoError(t, err)
+
+	var sb strings.Builder
+	require.NoError(t, Minify(&sb, payload, nil))
+	require.Equal(t, "events{worker_connections 1024;}http{server{listen 127.0.0.1:8080;"+
+		"server_name default_server;location /{return 200 \"foo bar baz\";}}}", sb.String())
+
+	sb.Reset()
+	require.NoError(t, Minify(&sb, &Payload{}, nil))
+	require.Empty(t, sb.String())
+}
+
+func TestMinifyReparse(t *testing.T) {
+	t.Parallel()
+	luaOptions := LexOptions{Lexers: []RegisterLexer{lua.RegisterLexer()}}
+	for _, fixture := range []struct {
+		name    string
+		options ParseOptions
+		minify  MinifyOptions
+	}{
+		{"simple", ParseOptions{}, MinifyOptions{}},
+		{"messy", ParseOptions{ParseComments: true}, MinifyOptions{}},
+		{"attached-comments", ParseOptions{AttachComments: true}, MinifyOptions{}},
+		{"if-expr", ParseOptions{}, MinifyOptions{}},
+		{"quote-behavior", ParseOptions{}, MinifyOptions{}},
+		{"quoted-right-brace", ParseOptions{}, MinifyOptions{}},
+		{"russian-text", ParseOptions{}, MinifyOptions{}},
+		{"types", ParseOptions{}, MinifyOptions{}},
+		{"includes-regular", ParseOptions{}, MinifyOptions{}},
+		{"includes-regular", ParseOptions{}, MinifyOptions{InlineIncludes: true}},
+		{"includes-globbed", ParseOptions{}, MinifyOptions{InlineIncludes: true}},
+		{
+			"lua-block-tricky",
+			ParseOptions{LexOptions: luaOptions},
+			MinifyOptions{Builders: []RegisterBuilder{lua.RegisterBuilder()}},
+		},
+	} {
+		fixture := fixture
+		t.Run(fixture.name, func(t *testing.T) {
+			t.Parallel()
+			payload, err := Parse(getTestConfigPath(fixture.name, "nginx.conf"), &fixture.options)
+			require.NoError(t, err)
+
+			if !fixture.minify.InlineIncludes {
+				for i, config := range payload.Config {
+					var sb strings.Builder
+					require.NoError(t, MinifyConfig(&sb, config, &fixture.minify))
+					requireMinified(t, config, sb.String(), fixture.options.LexOptions, i > 0)
+				}
+				return
+			}
+
+			var sb strings.Builder
+			require.NoError(t, Minify(&sb, payload, &fixture.minify))
+			combined, err := payload.Combined()
+			require.NoError(t, err)
+			requireMinified(t, combined.Config[0], sb.String(), fixture.options.LexOptions, false)
+		})
+	}
+}
+
+func TestMinifyIncludes(t *testing.T) {
+	t.Parallel()
+	payload, err := Parse(getTestConfigPath("includes-regular", "nginx.conf"), &ParseOptions{})
+	require.NoError(t, err)
+	require.Greater(t, len(payload.Config), 1)
+
+	var sb strings.Builder
+	require.EqualError(t, Minify(&sb, payload, nil),
+		"payload has several configs, minify them with MinifyConfig or set InlineIncludes")
+	require.Empty(t, sb.String())
+
+	require.NoError(t, MinifyConfig(&sb, payload.Config[1], nil))
+	require.Equal(t, "server{listen 127.0.0.1:8080;server_name default_server;include foo.conf;include bar.conf;}", sb.String())
+}
+
+// requireMinified parses minified and checks that it has the directives of config. The
+// contexts of included configs aren't known, so they aren't checked.
+func requireMinified(t *testing.T, config Config, minified string, lexOptions LexOptions, included bool) {
+	t.Helper()
+	payload, err := Parse(config.File, &ParseOptions{
+		Open:                      func(string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(minified)), nil },
+		SingleFile:                true,
+		LexOptions:                lexOptions,
+		SkipDirectiveContextCheck: included,
+	})
+	require.NoError(t, err)
+	require.Empty(t, payload.Errors)
+	require.True(t, equalBlocks(
+		stripLines(stripComments(config.Parsed)),
+		stripLines(payload.Config[0].Parsed),
+	), minified)
+}
+
+// stripComments returns the directives of block without comments or the indexes of the
+// configs they include, which a single minified config can't have.
+func stripComments(block Directives) Directives {
+	stripped := Directives{}
+	for _, stmt := range block {
+		if stmt.IsComment() {
+			continue
+		}
+		stmt := *stmt
+		stmt.LeadingComments = nil
+		stmt.TrailingComment = nil
+		stmt.ArgComments = nil
+		stmt.Includes = nil
+		if stmt.IsBlock() {
+			stmt.Block = stripComments(stmt.Block)
+		}
+		stripped = append(stripped, &stmt)
+	}
+	return stripped
+}
@@ -8,6 +8,7 @@
 package crossplane
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -43,14 +44,54 @@ type fileCtx struct {
 	ctx  blockCtx
 }
 
+// commentBlankLines returns the number of blank lines after each of the comments, given
+// the number of blank lines after the last one, or nil if there are none.
+func commentBlankLines(comments []pendingComment, last int) []int {
+	var blank []int
+	for i := range comments {
+		n := last
+		if i+1 < len(comments) {
+			n = comments[i+1].blankLines
+		}
+		if n > 0 && blank == nil {
+			blank = make([]int, len(comments))
+		}
+		if blank != nil {
+			blank[i] = n
+		}
+	}
+	return blank
+}
+
+// pendingComment is a comment waiting for the directive it will be attached to.
+type pendingComment struct {
+	tok        NgxToken
+	blankLines int
+}
+
 type parser struct {
-	configDir       string
-	options         *ParseOptions
-	handleError     func(*Config, error)
-	includes        []fileCtx
-	included        map[string]int
-	includeEdges    map[string][]string
-	includeInDegree map[string]int
+	configDir    string
+	options      *ParseOptions
+	handleError  func(*Config, error)
+	includes     []fileCtx
+	included     map[string]int
+	includeGraph *IncludeGraph
+
+	// events is set by ParseEvents, which reports the directives to its handler
+	// instead of keeping them in the Config
+	events   *ParseHandler
+	eventErr error
+	// ctx is the block context of the directive being parsed
+	ctx blockCtx
+
+	// with AttachComments, the block directive whose block is about to be parsed and
+	// the line of its "{", and the line of the "}" that ended the last block
+	blockOwner *Directive
+	blockLine  int
+	closeLine  int
+
+	// lastLine is the line the last token read ended on
+	lastLine int
 }
 
 // MatchFunc is the signature of the match function used to identify NGINX directives that
@@ -93,6 +134,18 @@ type ParseOptions struct {
 	// If true, comments will be parsed and added to the resulting Payload.
 	ParseComments bool
 
+	// If true, the blank lines before each directive and after its leading comments, and
+	// the line breaks between its arguments, are recorded in BlankLinesBefore,
+	// LeadingCommentBlankLines and ArgBreaks, so that Build can reproduce them.
+	KeepFormatting bool
+
+	// If true, comments are parsed and attached to the directives they belong to, as
+	// their LeadingComments, TrailingComment and ArgComments, so that they move with
+	// the directive. Comments that don't belong to a directive, like those at the end
+	// of a block or after a "}", are added as "#" directives like with ParseComments.
+	// In ParseEvents, the TrailingComment of a directive is set after its event.
+	AttachComments bool
+
 	// If true, add an error to the payload when encountering a directive that
 	// is unrecognized. The unrecognized directive will not be included in the
 	// resulting Payload.
@@ -104,12 +157,36 @@ type ParseOptions struct {
 	// If true, checks that directives have a valid number of arguments.
 	SkipDirectiveArgsCheck bool
 
+	// If true, after all files are parsed, add an error to the payload for every
+	// reference to a zone, upstream, log format or named location that is never
+	// defined. See Index.Dangling for the rules used.
+	CheckReferences bool
+
+	// If true, after all files are parsed, add an error to the payload for every
+	// directive repeated in a block although NGINX only allows it once, and for
+	// conflicting listen and server_name directives across http servers.
+	CheckDuplicates bool
+
+	// Registry holds the specs CheckDuplicates uses to know which directives may only
+	// appear once in a block, see DirectiveSpec.Unique. If it is nil, DefaultRegistry
+	// is used. It doesn't change the directives the parser expects, see DirectiveSources.
+	Registry *Registry
+
+	// BlockContexts adds block contexts, like those of third-party modules, or replaces
+	// the predefined contexts with the same Path. Directives in a block context that
+	// is neither predefined nor in BlockContexts aren't checked.
+	BlockContexts []BlockContext
+
 	// DirectiveSources is used to indicate the set of directives to be expected
 	// by the parser. DirectiveSources can include different versions of NGINX
 	// and dynamic modules. If DirectiveSources is empty, the parser defaults
 	// to DefaultDirectivesMatchFunc.
 	DirectiveSources []MatchFunc
 
+	// If specified, environment variables are substituted in every config file
+	// before it is lexed. Line numbers still refer to the unsubstituted file.
+	Envsubst *EnvsubstOptions
+
 	LexOptions LexOptions
 }
 
@@ -122,82 +199,147 @@ func Parse(filename string, options *ParseOptions) (*Payload, error) {
 		Errors: []PayloadError{},
 		Config: []Config{},
 	}
-	if options.Glob == nil {
-		options.Glob = filepath.Glob
+	handleError := func(config *Config, err error) {
+		payload.addError(config, err, options)
 	}
 
-	handleError := func(config *Config, err error) {
-		var line *int
-		if e, ok := err.(*ParseError); ok {
-			line = e.Line
+	// Start with the main nginx config file/context.
+	p := newParser(filename, options, handleError)
+
+	for len(p.includes) > 0 {
+		config, err := p.parseNextFile()
+		if err != nil {
+			return nil, err
+		}
+		payload.Config = append(payload.Config, *config)
+	}
+
+	if cycle := p.includeGraph.Cycle(); cycle != nil {
+		return nil, fmt.Errorf("configs contain include cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	payload.IncludeGraph = p.includeGraph
+
+	var crossFileErrs []*ParseError
+	if options.CheckReferences {
+		crossFileErrs = append(crossFileErrs, newIndex(payload, options.BlockContexts).Dangling()...)
+	}
+	if options.CheckDuplicates {
+		registry := options.Registry
+		if registry == nil {
+			registry = DefaultRegistry()
 		}
-		cerr := ConfigError{Line: line, Error: err}
-		perr := PayloadError{Line: line, Error: err, File: config.File}
-		if options.ErrorCallback != nil {
-			perr.Callback = options.ErrorCallback(err)
+		crossFileErrs = append(crossFileErrs, checkDuplicates(payload, registry, options.BlockContexts)...)
+	}
+	for _, perr := range crossFileErrs {
+		if options.StopParsingOnError {
+			return nil, perr
 		}
+		handleError(&payload.Config[p.included[*perr.File]], perr)
+	}
 
-		const failedSts = "failed"
-		config.Status = failedSts
-		config.Errors = append(config.Errors, cerr)
+	if options.CombineConfigs {
+		return payload.Combined()
+	}
 
-		payload.Status = failedSts
-		payload.Errors = append(payload.Errors, perr)
+	return payload, nil
+}
+
+// addError records an error found in the config, which must be one of the payload's
+// configs, and marks both as failed.
+func (p *Payload) addError(config *Config, err error, options *ParseOptions) {
+	var line *int
+	if e, ok := err.(*ParseError); ok {
+		line = e.Line
+	}
+	cerr := ConfigError{Line: line, Error: err}
+	perr := PayloadError{Line: line, Error: err, File: config.File}
+	if options.ErrorCallback != nil {
+		perr.Callback = options.ErrorCallback(err)
 	}
 
-	// Start with the main nginx config file/context.
-	p := parser{
+	const failedSts = "failed"
+	config.Status = failedSts
+	config.Errors = append(config.Errors, cerr)
+
+	p.Status = failedSts
+	p.Errors = append(p.Errors, perr)
+}
+
+func newParser(filename string, options *ParseOptions, handleError func(*Config, error)) *parser {
+	if options.Glob == nil {
+		options.Glob = filepath.Glob
+	}
+	return &parser{
 		configDir:   filepath.Dir(filename),
 		options:     options,
 		handleError: handleError,
 		includes:    []fileCtx{{path: filename, ctx: blockCtx{}}},
 		included:    map[string]int{filename: 0},
-		// adjacency list where an edge exists between a file and the file it includes
-		includeEdges: map[string][]string{},
-		// number of times a file is included by another file
-		includeInDegree: map[string]int{filename: 0},
+		// every include directive adds an edge between its file and the files it includes
+		includeGraph: newIncludeGraph(filename),
 	}
+}
 
-	for len(p.includes) > 0 {
-		incl := p.includes[0]
-		p.includes = p.includes[1:]
+// parseNextFile parses the next file waiting in the includes queue.
+func (p *parser) parseNextFile() (*Config, error) {
+	incl := p.includes[0]
+	p.includes = p.includes[1:]
+	p.ctx = incl.ctx
+	p.lastLine = 0
 
-		file, err := p.openFile(incl.path)
-		if err != nil {
-			return nil, err
-		}
+	file, err := p.openFile(incl.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
 
-		defer file.Close()
+	config := &Config{
+		File:   incl.path,
+		Status: "ok",
+		Errors: []ConfigError{},
+		Parsed: Directives{},
+	}
 
-		tokens := LexWithOptions(file, options.LexOptions)
-		config := Config{
-			File:   incl.path,
-			Status: "ok",
-			Errors: []ConfigError{},
-			Parsed: Directives{},
-		}
-		parsed, err := p.parse(&config, tokens, incl.ctx, false)
+	var tokens <-chan NgxToken
+	if p.options.Envsubst != nil {
+		res, err := envsubst(file, incl.path, p.options.Envsubst)
 		if err != nil {
-			if options.StopParsingOnError {
-				return nil, err
+			return nil, err
+		}
+		for _, perr := range res.unresolved {
+			if p.options.StopParsingOnError {
+				return nil, perr
 			}
-			handleError(&config, err)
-		} else {
-			config.Parsed = parsed
+			p.handleError(config, perr)
 		}
-
-		payload.Config = append(payload.Config, config)
+		tokens = res.remapLines(LexWithOptions(bytes.NewReader(res.data), p.options.LexOptions))
+	} else {
+		tokens = LexWithOptions(file, p.options.LexOptions)
 	}
 
-	if p.isAcyclic() {
-		return nil, errors.New("configs contain include cycle")
+	parsed, err := p.parse(config, tokens, incl.ctx, false)
+	switch {
+	case p.eventErr != nil:
+		return nil, p.eventErr
+	case err != nil && p.options.StopParsingOnError:
+		return nil, err
+	case err != nil:
+		p.handleError(config, err)
+	default:
+		config.Parsed = parsed
 	}
+	return config, p.eventErr
+}
 
-	if options.CombineConfigs {
-		return payload.Combined()
+// advance records that a token was read and returns the number of blank lines before it.
+func (p *parser) advance(t NgxToken) int {
+	blankLines := 0
+	if p.lastLine > 0 && t.Line > p.lastLine+1 {
+		blankLines = t.Line - p.lastLine - 1
 	}
-
-	return payload, nil
+	p.lastLine = t.Line + strings.Count(t.Value, "\n")
+	return blankLines
 }
 
 func (p *parser) openFile(path string) (io.ReadCloser, error) {
@@ -213,8 +355,50 @@ func (p *parser) openFile(path string) (io.ReadCloser, error) {
 //nolint:gocyclo,funlen,gocognit,maintidx,nonamedreturns
 func (p *parser) parse(parsing *Config, tokens <-chan NgxToken, ctx blockCtx, consume bool) (parsed Directives, err error) {
 	var tokenOk bool
+	if !consume {
+		p.ctx = ctx
+	}
+
+	// state for attaching comments: the block directive whose "{" is on ownerLine, the
+	// last directive and the line it ended on, the line of the "}" of the last block,
+	// and the comments waiting for a directive
+	owner, ownerLine := p.blockOwner, p.blockLine
+	p.blockOwner = nil
+	var prev *Directive
+	var prevEnd int
+	closeLine := -1
+	var pending []pendingComment
+
+	// flushPending adds the comments waiting for a directive as "#" directives
+	flushPending := func() error {
+		for _, c := range pending {
+			comment := c.tok.Value[1:]
+			stmt := &Directive{
+				Directive: "#",
+				Line:      c.tok.Line,
+				Args:      []string{},
+				Comment:   &comment,
+			}
+			if p.options.KeepFormatting {
+				stmt.BlankLinesBefore = c.blankLines
+			}
+			if p.options.CombineConfigs {
+				stmt.File = parsing.File
+			}
+			if err := p.emit(p.events.comment(), parsing, stmt, ctx, nil); err != nil {
+				return err
+			}
+			parsed = p.keep(parsed, stmt)
+		}
+		pending = nil
+		return nil
+	}
+
 	// parse recursively by pulling from a flat stream of tokens
 	for t := range tokens {
+		if p.eventErr != nil {
+			return nil, p.eventErr
+		}
 		if t.Error != nil {
 			var perr *ParseError
 			if errors.As(t.Error, &perr) {
@@ -232,9 +416,11 @@ func (p *parser) parse(parsing *Config, tokens <-chan NgxToken, ctx blockCtx, co
 		}
 
 		var commentsInArgs []string
+		blankLines := p.advance(t)
 
 		// we are parsing a block, so break if it's closing
 		if t.Value == "}" && !t.IsQuoted {
+			p.closeLine = t.Line
 			break
 		}
 
@@ -259,18 +445,56 @@ func (p *parser) parse(parsing *Config, tokens <-chan NgxToken, ctx blockCtx, co
 			Args:      []string{},
 			File:      fileName,
 		}
+		if p.options.KeepFormatting {
+			stmt.BlankLinesBefore = blankLines
+		}
 
 		// if token is comment
 		if strings.HasPrefix(t.Value, "#") && !t.IsQuoted {
+			if p.options.AttachComments {
+				comment := t.Value[1:]
+				switch {
+				case owner != nil && t.Line == ownerLine:
+					owner.TrailingComment = &comment
+					owner = nil
+				case prev != nil && t.Line == prevEnd:
+					prev.TrailingComment = &comment
+					prev = nil
+				case t.Line == closeLine:
+					// a comment after the "}" of the previous block
+					stmt.Directive = "#"
+					stmt.Comment = &comment
+					if err := p.emit(p.events.comment(), parsing, stmt, ctx, nil); err != nil {
+						return nil, err
+					}
+					parsed = p.keep(parsed, stmt)
+				default:
+					pending = append(pending, pendingComment{tok: t, blankLines: blankLines})
+				}
+				continue
+			}
 			if p.options.ParseComments {
 				comment := t.Value[1:]
 				stmt.Directive = "#"
 				stmt.Comment = &comment
-				parsed = append(parsed, stmt)
+				if err := p.emit(p.events.comment(), parsing, stmt, ctx, nil); err != nil {
+					return nil, err
+				}
+				parsed = p.keep(parsed, stmt)
 			}
 			continue
 		}
 
+		owner, prev, closeLine = nil, nil, -1
+		if len(pending) > 0 && p.options.KeepFormatting {
+			stmt.BlankLinesBefore = pending[0].blankLines
+			stmt.LeadingCommentBlankLines = commentBlankLines(pending, blankLines)
+		}
+		for _, c := range pending {
+			stmt.LeadingComments = append(stmt.LeadingComments, c.tok.Value[1:])
+		}
+		pending = nil
+
 		// parse arguments by reading tokens
 		t, tokenOk = <-tokens
 		if !tokenOk {
@@ -283,8 +507,15 @@ func (p *parser) parse(parsing *Config, tokens <-chan NgxToken, ctx blockCtx, co
 			}
 		}
 		for t.IsQuoted || (t.Value != "{" && t.Value != ";" && t.Value != "}") {
+			newLine := t.Line > p.lastLine
+			p.advance(t)
 			if !strings.HasPrefix(t.Value, "#") || t.IsQuoted {
+				if newLine && p.options.KeepFormatting {
+					stmt.ArgBreaks = append(stmt.ArgBreaks, len(stmt.Args))
+				}
 				stmt.Args = append(stmt.Args, t.Value)
+			} else if p.options.AttachComments {
+				stmt.ArgComments = append(stmt.ArgComments, ArgComment{Index: len(stmt.Args), Comment: t.Value[1:]})
 			} else if p.options.ParseComments {
 				commentsInArgs = append(commentsInArgs, t.Value[1:])
 			}
@@ -299,6 +530,7 @@ func (p *parser) parse(parsing *Config, tokens <-chan NgxToken, ctx blockCtx, co
 				}
 			}
 		}
+		p.advance(t)
 
 		// if inside "map-like" block - add contents to payload, but do not parse further
 		if len(ctx) > 0 {
@@ -314,7 +546,11 @@ func (p *parser) parse(parsing *Config, tokens <-chan NgxToken, ctx blockCtx, co
 					}
 					continue
 				}
-				parsed = append(parsed, stmt)
+				if err := p.emit(p.events.directive(), parsing, stmt, ctx, nil); err != nil {
+					return nil, err
+				}
+				parsed = p.keep(parsed, stmt)
+				prev, prevEnd = stmt, t.Line
 				continue
 			}
 		}
@@ -353,6 +589,7 @@ func (p *parser) parse(parsing *Config, tokens <-chan NgxToken, ctx blockCtx, co
 		}
 
 		// add "includes" to the payload if this is an include statement
+		var resolved []string
 		if !p.options.SingleFile && stmt.Directive == "include" {
 			if len(stmt.Args) == 0 {
 				return nil, &ParseError{
@@ -368,6 +605,7 @@ func (p *parser) parse(parsing *Config, tokens <-chan NgxToken, ctx blockCtx, co
 				}
 			}
 
+			resolved = []string{}
 			pattern := stmt.Args[0]
 			if !filepath.IsAbs(pattern) {
 				pattern = filepath.Join(p.configDir, pattern)
@@ -380,6 +618,9 @@ func (p *parser) parse(parsing *Config, tokens <-chan NgxToken, ctx blockCtx, co
 				if err != nil {
 					return nil, err
 				}
+				if len(fnames) == 0 {
+					p.includeGraph.addUnmatchedGlob(parsing.File, stmt.Line, stmt.Args[0], ctx)
+				}
 				sort.Strings(fnames)
 			} else {
 				// if the file pattern was explicit, nginx will check
@@ -409,72 +650,67 @@ func (p *parser) parse(parsing *Config, tokens <-chan NgxToken, ctx blockCtx, co
 				if _, ok := p.included[fname]; !ok {
 					p.included[fname] = len(p.included)
 					p.includes = append(p.includes, fileCtx{fname, ctx})
+					p.includeGraph.addNode(fname)
 				}
 				stmt.Includes = append(stmt.Includes, p.included[fname])
-				// add edge between the current file and it's included file and
-				// increase the included file's in degree
-				p.includeEdges[parsing.File] = append(p.includeEdges[parsing.File], fname)
-				p.includeInDegree[fname]++
+				resolved = append(resolved, fname)
+				// add edge between the current file and it's included file
+				p.includeGraph.addEdge(parsing.File, fname, stmt.Line, stmt.Args[0], ctx)
 			}
 		}
 
 		// if this statement terminated with "{" then it is a block
 		if t.Value == "{" && !t.IsQuoted {
 			stmt.Block = make(Directives, 0)
-			inner := enterBlockCtx(stmt, ctx) // get context for block
+			if err := p.emit(p.events.startBlock(), parsing, stmt, ctx, nil); err != nil {
+				return nil, err
+			}
+			inner := enterBlockCtx(stmt, ctx, p.options.BlockContexts) // get context for block
+			p.blockOwner, p.blockLine = stmt, t.Line
 			blocks, err := p.parse(parsing, tokens, inner, false)
 			if err != nil {
 				return nil, err
 			}
+			p.ctx = ctx
+			closeLine = p.closeLine
 			stmt.Block = append(stmt.Block, blocks...)
+			if err := p.emit(p.events.endBlock(), parsing, stmt, ctx, nil); err != nil {
+				return nil, err
+			}
+		} else if err := p.emit(p.events.directive(), parsing, stmt, ctx, nil); err != nil {
+			return nil, err
+		} else {
+			prev, prevEnd = stmt, t.Line
+		}
+		if resolved != nil {
+			if err := p.emit(p.events.includeResolved(), parsing, stmt, ctx, resolved); err != nil {
+				return nil, err
+			}
 		}
 
-		parsed = append(parsed, stmt)
+		parsed = p.keep(parsed, stmt)
 
 		// add all comments found inside args after stmt is added
 		for _, comment := range commentsInArgs {
 			comment := comment
-			parsed = append(parsed, &Directive{
+			commentStmt := &Directive{
 				Directive: "#",
 				Line:      stmt.Line,
 				Args:      []string{},
 				File:      fileName,
 				Comment:   &comment,
-			})
+			}
+			if err := p.emit(p.events.comment(), parsing, commentStmt, ctx, nil); err != nil {
+				return nil, err
+			}
+			parsed = p.keep(parsed, commentStmt)
 		}
 	}
 
-	return parsed, nil
-}
-
-// isAcyclic performs a topological sort to check if there are cycles created by configs' includes.
-// First, it adds any files who are not being referenced by another file to a queue (in degree of 0).
-// For every file in the queue, it will remove the reference it has towards its neighbors.
-// At the end, if the queue is empty but not all files were once in the queue,
-// then files still exist with references, and therefore, a cycle is present.
-func (p *parser) isAcyclic() bool {
-	// add to queue if file is not being referenced by any other file
-	var queue []string
-	for k, v := range p.includeInDegree {
-		if v == 0 {
-			queue = append(queue, k)
-		}
+	// comments that no directive followed
+	if err := flushPending(); err != nil {
+		return nil, err
 	}
-	fileCount := 0
-	for len(queue) > 0 {
-		// dequeue
-		file := queue[0]
-		queue = queue[1:]
-		fileCount++
-
-		// decrease each neighbor's in degree
-		neighbors := p.includeEdges[file]
-		for _, f := range neighbors {
-			p.includeInDegree[f]--
-			if p.includeInDegree[f] == 0 {
-				queue = append(queue, f)
-			}
-		}
-	}
-	return fileCount != len(p.includeInDegree)
+
+	return parsed, nil
 }
@@ -700,6 +700,93 @@ var parseFixtures = []parseFixture{
 			},
 		},
 	}},
+	{"attached-comments", "", ParseOptions{AttachComments: true}, Payload{
+		Status: "ok",
+		Config: []Config{
+			{
+				File:   getTestConfigPath("attached-comments", "nginx.conf"),
+				Status: "ok",
+				Parsed: Directives{
+					{
+						Directive:       "user",
+						Args:            []string{"nginx"},
+						Line:            2,
+						LeadingComments: []string{" main settings"},
+						TrailingComment: pStr(" unprivileged"),
+					},
+					{
+						Directive: "events",
+						Args:      []string{},
+						Line:      3,
+						Block: Directives{
+							{
+								Directive: "worker_connections",
+								Args:      []string{"1024"},
+								Line:      4,
+							},
+						},
+					},
+					{
+						Directive:       "http",
+						Args:            []string{},
+						Line:            7,
+						TrailingComment: pStr(" http"),
+						Block: Directives{
+							{
+								Directive:       "server",
+								Args:            []string{},
+								Line:            10,
+								LeadingComments: []string{" the only server", " of this config"},
+								Block: Directives{
+									{
+										Directive: "listen",
+										Args:      []string{"127.0.0.1:8080"},
+										Line:      11,
+									},
+									{
+										Directive:   "server_name",
+										Args:        []string{"example.com", "www.example.com"},
+										Line:        12,
+										ArgComments: []ArgComment{{Index: 1, Comment: " primary"}},
+									},
+									{
+										Directive: "location",
+										Args:      []string{"/"},
+										Line:      14,
+										Block: Directives{
+											{
+												Directive: "return",
+												Args:      []string{"200", "ok"},
+												Line:      15,
+											},
+											{
+												Directive: "#",
+												Args:      []string{},
+												Line:      16,
+												Comment:   pStr(" end of location"),
+											},
+										},
+									},
+									{
+										Directive: "#",
+										Args:      []string{},
+										Line:      17,
+										Comment:   pStr(" location /"),
+									},
+								},
+							},
+						},
+					},
+					{
+						Directive: "#",
+						Args:      []string{},
+						Line:      20,
+						Comment:   pStr(" end of file"),
+					},
+				},
+			},
+		},
+	}},
 	{"spelling-mistake", "", ParseOptions{ParseComments: true, ErrorOnUnknownDirectives: true}, Payload{
 		Status: "failed",
 		Errors: []PayloadError{
@@ -2361,6 +2448,28 @@ func TestParseIfExpr(t *testing.T) {
 	}
 }
 
+func TestParseEmptyIfExpr(t *testing.T) {
+	t.Parallel()
+	// without the args check, an empty expression is parsed as an "if" without arguments
+	for _, fn := range []string{"empty-parens", "empty-spaced-parens"} {
+		path := getTestConfigPath("if-expr", fn+".conf")
+		payload, err := Parse(path, &ParseOptions{SingleFile: true, StopParsingOnError: true, SkipDirectiveArgsCheck: true})
+		require.NoError(t, err, path)
+		stmt := payload.Config[0].Parsed[1].Block[0].Block[2]
+		require.Equal(t, "if", stmt.Directive, path)
+		require.Empty(t, stmt.Args, path)
+		require.Len(t, stmt.Block, 1, path)
+	}
+}
+
+func TestPrepareIfArgsEmpty(t *testing.T) {
+	t.Parallel()
+	for _, args := range [][]string{{"()"}, {"(", ")"}, {"( ", " )"}} {
+		require.Empty(t, prepareIfArgs(&Directive{Directive: "if", Args: args}).Args, args)
+	}
+	require.Equal(t, []string{"$a"}, prepareIfArgs(&Directive{Directive: "if", Args: []string{"($a)"}}).Args)
+}
+
 func TestBalancingBraces(t *testing.T) {
 	t.Parallel()
 	tcs := map[string]struct {
@@ -2407,6 +2516,98 @@ func TestCombinedIncludes(t *testing.T) {
 	}
 }
 
+func TestIncludeCyclePath(t *testing.T) {
+	t.Parallel()
+	path := getTestConfigPath("includes-cycle", "invalid", "nginx.conf")
+	location1 := getTestConfigPath("includes-cycle", "invalid", "location1.conf")
+	location2 := getTestConfigPath("includes-cycle", "invalid", "location2.conf")
+
+	_, err := Parse(path, &ParseOptions{})
+	require.EqualError(t, err, "configs contain include cycle: "+location1+" -> "+location2+" -> "+location1)
+}
+
+func TestParseCheckReferences(t *testing.T) {
+	t.Parallel()
+	path := getTestConfigPath("index", "nginx.conf")
+
+	payload, err := Parse(path, &ParseOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "ok", payload.Status)
+
+	payload, err = Parse(path, &ParseOptions{CheckReferences: true})
+	require.NoError(t, err)
+	require.Equal(t, "failed", payload.Status)
+	require.Len(t, payload.Errors, 6)
+	require.Equal(t, path, payload.Errors[0].File)
+	require.Equal(t, pInt(31), payload.Errors[0].Line)
+	require.EqualError(t, payload.Errors[0].Error, `unknown limit_req zone "missing" in `+path+`:31`)
+	require.Equal(t, "failed", payload.Config[0].Status)
+	require.Len(t, payload.Config[0].Errors, 6)
+	require.Equal(t, "ok", payload.Config[1].Status)
+
+	_, err = Parse(path, &ParseOptions{CheckReferences: true, StopParsingOnError: true})
+	require.EqualError(t, err, `unknown limit_req zone "missing" in `+path+`:31`)
+}
+
+func TestParseCheckDuplicates(t *testing.T) {
+	t.Parallel()
+	path := getTestConfigPath("duplicates", "nginx.conf")
+	root := getTestConfigPath("duplicates", "root.conf")
+
+	payload, err := Parse(path, &ParseOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "ok", payload.Status)
+
+	payload, err = Parse(path, &ParseOptions{CheckDuplicates: true})
+	require.NoError(t, err)
+	var got []string
+	for _, e := range payload.Errors {
+		got = append(got, e.Error.Error())
+	}
+	require.Equal(t, []string{
+		`"root" directive is duplicate in ` + root + `:2`,
+		`a duplicate default server for *:80 in ` + path + `:15`,
+		`duplicate listen options for *:443 in ` + path + `:16`,
+		`conflicting server name "EXAMPLE.com" on *:80 in ` + path + `:17`,
+		`conflicting server name "EXAMPLE.com" on *:443 in ` + path + `:17`,
+	}, got)
+	require.Equal(t, "failed", payload.Config[1].Status)
+	require.Len(t, payload.Config[1].Errors, 1)
+
+	// the directives that may not repeat come from the specs of the registry
+	payload, err = Parse(path, &ParseOptions{
+		CheckDuplicates: true,
+		Registry:        NewRegistry(DirectiveSpec{Name: "index", Contexts: ContextHTTPLocation, Args: Args1OrMore, Unique: true}),
+	})
+	require.NoError(t, err)
+	got = nil
+	for _, e := range payload.Errors {
+		got = append(got, e.Error.Error())
+	}
+	require.Equal(t, []string{
+		`"index" directive is duplicate in ` + path + `:11`,
+		`a duplicate default server for *:80 in ` + path + `:15`,
+		`duplicate listen options for *:443 in ` + path + `:16`,
+		`conflicting server name "EXAMPLE.com" on *:80 in ` + path + `:17`,
+		`conflicting server name "EXAMPLE.com" on *:443 in ` + path + `:17`,
+	}, got)
+}
+
+func TestListenAddress(t *testing.T) {
+	t.Parallel()
+	for arg, expected := range map[string]string{
+		"80":               "*:80",
+		"127.0.0.1":        "127.0.0.1:80",
+		"0.0.0.0:8080":     "*:8080",
+		"[::]:443":         "[::]:443",
+		"[::1]":            "[::1]:80",
+		"localhost:81":     "localhost:81",
+		"unix:/tmp/a.sock": "unix:/tmp/a.sock",
+	} {
+		require.Equal(t, expected, listenAddress(arg), arg)
+	}
+}
+
 func TestDefaultUbuntu(t *testing.T) {
 	t.Parallel()
 	path := getTestConfigPath("ubuntu-default", "nginx.conf")
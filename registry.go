@@ -0,0 +1,268 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"sort"
+)
+
+// ContextMask is a set of the block contexts a directive is allowed in.
+type ContextMask uint
+
+// The contexts of ContextMask. Their values are the bits used in the masks of a MatchFunc.
+const (
+	ContextMain            ContextMask = ngxMainConf
+	ContextEvents          ContextMask = ngxEventConf
+	ContextMail            ContextMask = ngxMailMainConf
+	ContextMailServer      ContextMask = ngxMailSrvConf
+	ContextStream          ContextMask = ngxStreamMainConf
+	ContextStreamServer    ContextMask = ngxStreamSrvConf
+	ContextStreamUpstream  ContextMask = ngxStreamUpsConf
+	ContextHTTP            ContextMask = ngxHTTPMainConf
+	ContextHTTPServer      ContextMask = ngxHTTPSrvConf
+	ContextHTTPLocation    ContextMask = ngxHTTPLocConf
+	ContextHTTPUpstream    ContextMask = ngxHTTPUpsConf
+	ContextHTTPServerIf    ContextMask = ngxHTTPSifConf
+	ContextHTTPLocationIf  ContextMask = ngxHTTPLifConf
+	ContextHTTPLimitExcept ContextMask = ngxHTTPLmtConf
+	ContextMgmt            ContextMask = ngxMgmtMainConf
+
+	// ContextAny is every context but mgmt, like NGX_ANY_CONF.
+	ContextAny ContextMask = ngxAnyConf
+)
+
+// argBits are the bits of a mask that hold its arguments, the others hold its contexts.
+const argBits = 0x0000ffff
+
+// ContextMaskOf returns the ContextMask of a predefined block context, like
+// ["http", "server"]. It returns false if the context isn't known.
+func ContextMaskOf(ctx []string) (ContextMask, bool) {
+	mask, ok := contexts[blockCtx(ctx).key()]
+	return ContextMask(mask), ok
+}
+
+// Has reports whether m includes all of the contexts of c.
+func (m ContextMask) Has(c ContextMask) bool {
+	return m&c == c
+}
+
+// ArgSpec is a set of the numbers of arguments a directive accepts.
+type ArgSpec uint
+
+// The argument styles of ArgSpec. Their values are the bits used in the masks of a MatchFunc.
+const (
+	ArgsNone    ArgSpec = ngxConfNoArgs
+	Args1       ArgSpec = ngxConfTake1
+	Args2       ArgSpec = ngxConfTake2
+	Args3       ArgSpec = ngxConfTake3
+	Args4       ArgSpec = ngxConfTake4
+	Args5       ArgSpec = ngxConfTake5
+	Args6       ArgSpec = ngxConfTake6
+	ArgsFlag    ArgSpec = ngxConfFlag  // "on" or "off"
+	ArgsAny     ArgSpec = ngxConfAny   // any number of arguments
+	Args1OrMore ArgSpec = ngxConf1More // at least one argument
+	Args2OrMore ArgSpec = ngxConf2More // at least two arguments
+
+	// ArgsExpr is for directives like "if" whose arguments are an expression in
+	// parentheses. It is used along with the number of arguments of the expression.
+	ArgsExpr ArgSpec = ngxConfExpr
+
+	Args12   = Args1 | Args2
+	Args13   = Args1 | Args3
+	Args23   = Args2 | Args3
+	Args34   = Args3 | Args4
+	Args123  = Args12 | Args3
+	Args1234 = Args123 | Args4
+)
+
+// Allows reports whether a directive with n arguments matches a. The value of a flag
+// isn't checked.
+func (a ArgSpec) Allows(n int) bool {
+	return (n <= 7 && a>>n&1 != 0) ||
+		(a&ArgsFlag != 0 && n == 1) ||
+		a&ArgsAny != 0 ||
+		(a&Args1OrMore != 0 && n >= 1) ||
+		(a&Args2OrMore != 0 && n >= 2)
+}
+
+// DirectiveSpec describes where a directive is allowed and which arguments it accepts.
+// A directive that behaves differently in different contexts, like access_log in http
+// and stream, has a DirectiveSpec for each of them.
+type DirectiveSpec struct {
+	Name     string
+	Contexts ContextMask
+	Args     ArgSpec
+	Block    bool
+
+	// Module is the module or the set of modules the directive comes from, like "njs".
+	Module string
+
+	// MinVersion and MaxVersion are the first and last versions of Module that have the
+	// directive. They are empty when the version isn't known or not bounded.
+	MinVersion string
+	MaxVersion string
+
+	// Unique is true if NGINX rejects the directive with "directive is duplicate" when
+	// it appears more than once in a block. Parse uses it for CheckDuplicates.
+	Unique bool
+
+	// Deprecations are the deprecations of the directive, for CheckDeprecations. Their
+	// Directive is Name, and their Contexts and Product default to Contexts and Module.
+	Deprecations []Deprecation
+}
+
+// equal reports whether s and o are the same spec.
+func (s DirectiveSpec) equal(o DirectiveSpec) bool {
+	if s.Name != o.Name || s.Contexts != o.Contexts || s.Args != o.Args || s.Block != o.Block ||
+		s.Module != o.Module || s.MinVersion != o.MinVersion || s.MaxVersion != o.MaxVersion ||
+		s.Unique != o.Unique || len(s.Deprecations) != len(o.Deprecations) {
+		return false
+	}
+	for i := range s.Deprecations {
+		if s.Deprecations[i] != o.Deprecations[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Mask returns the bitmask of s, as used by a MatchFunc.
+func (s DirectiveSpec) Mask() uint {
+	mask := uint(s.Contexts) | uint(s.Args)
+	if s.Block {
+		mask |= ngxConfBlock
+	}
+	if s.Unique {
+		mask |= ngxConfUnique
+	}
+	return mask
+}
+
+func specFromMask(name string, module string, mask uint) DirectiveSpec {
+	spec := DirectiveSpec{
+		Name:     name,
+		Contexts: ContextMask(mask &^ argBits),
+		Args:     ArgSpec(mask & argBits &^ (ngxConfBlock | ngxConfUnique)),
+		Block:    mask&ngxConfBlock != 0,
+		Module:   module,
+		Unique:   mask&ngxConfUnique != 0,
+	}
+	spec.Deprecations = specDeprecations(spec)
+	return spec
+}
+
+// Registry is a set of DirectiveSpecs. Registries can be built from scratch, composed
+// and filtered, and turned into a MatchFunc for ParseOptions.DirectiveSources.
+type Registry struct {
+	specs map[string][]DirectiveSpec
+}
+
+// NewRegistry returns a Registry with the given specs.
+func NewRegistry(specs ...DirectiveSpec) *Registry {
+	r := &Registry{specs: make(map[string][]DirectiveSpec, len(specs))}
+	r.Add(specs...)
+	return r
+}
+
+// registryFromMasks returns a Registry with the directives of a generated table.
+func registryFromMasks(module string, directives map[string][]uint) *Registry {
+	r := &Registry{specs: make(map[string][]DirectiveSpec, len(directives))}
+	for name, masks := range directives {
+		for _, mask := range masks {
+			r.Add(specFromMask(name, module, mask))
+		}
+	}
+	return r
+}
+
+// ComposeRegistries returns a Registry with the specs of all of the registries.
+func ComposeRegistries(registries ...*Registry) *Registry {
+	composed := NewRegistry()
+	for _, r := range registries {
+		composed.Add(r.Specs()...)
+	}
+	return composed
+}
+
+// Add adds specs to r. A spec equal to one that r already has is ignored.
+func (r *Registry) Add(specs ...DirectiveSpec) {
+	if r.specs == nil {
+		r.specs = make(map[string][]DirectiveSpec, len(specs))
+	}
+outer:
+	for _, spec := range specs {
+		for _, s := range r.specs[spec.Name] {
+			if s.equal(spec) {
+				continue outer
+			}
+		}
+		r.specs[spec.Name] = append(r.specs[spec.Name], spec)
+	}
+}
+
+// Lookup returns the specs of the directive with the given name.
+func (r *Registry) Lookup(name string) ([]DirectiveSpec, bool) {
+	specs, ok := r.specs[name]
+	return specs, ok
+}
+
+// Len returns the number of directives in r.
+func (r *Registry) Len() int {
+	return len(r.specs)
+}
+
+// Names returns the sorted names of the directives in r.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.specs))
+	for name := range r.specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Specs returns the specs of r, sorted by directive name. The specs of a directive are
+// in the order they were added.
+func (r *Registry) Specs() []DirectiveSpec {
+	var specs []DirectiveSpec
+	for _, name := range r.Names() {
+		specs = append(specs, r.specs[name]...)
+	}
+	return specs
+}
+
+// Filter returns a Registry with the specs of r for which keep returns true.
+func (r *Registry) Filter(keep func(DirectiveSpec) bool) *Registry {
+	filtered := NewRegistry()
+	for _, spec := range r.Specs() {
+		if keep(spec) {
+			filtered.Add(spec)
+		}
+	}
+	return filtered
+}
+
+// MatchFunc returns a MatchFunc for the directives of r. Specs added to r afterwards
+// aren't seen by the MatchFunc.
+func (r *Registry) MatchFunc() MatchFunc {
+	masks := make(map[string][]uint, len(r.specs))
+	for name, specs := range r.specs {
+		for _, spec := range specs {
+			masks[name] = append(masks[name], spec.Mask())
+		}
+	}
+	return func(directive string) ([]uint, bool) {
+		m, ok := masks[directive]
+		return m, ok
+	}
+}
+
+// DefaultRegistry returns a Registry with the directives of DefaultDirectivesMatchFunc.
+func DefaultRegistry() *Registry {
+	return ComposeRegistries(NginxPlusLatestRegistry(), NjsLatestRegistry(), OtelLatestRegistry())
+}
@@ -0,0 +1,164 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"io"
+	"math/bits"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryMatchesTables(t *testing.T) {
+	t.Parallel()
+	for name, tc := range map[string]struct {
+		registry  *Registry
+		directive map[string][]uint
+		match     MatchFunc
+	}{
+		"oss":     {OssLatestRegistry(), ossLatestDirectives, MatchOssLatest},
+		"nplus":   {NginxPlusLatestRegistry(), nginxPlusLatestDirectives, MatchNginxPlusLatest},
+		"lua":     {LuaLatestRegistry(), luaDirectives, MatchLuaLatest},
+		"default": {DefaultRegistry(), defaultDirectives, DefaultDirectivesMatchFunc},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, len(tc.directive), tc.registry.Len())
+			match := tc.registry.MatchFunc()
+			for directive := range tc.directive {
+				want, _ := tc.match(directive)
+				got, ok := match(directive)
+				require.True(t, ok, directive)
+				require.ElementsMatch(t, want, got, directive)
+			}
+			_, ok := match("not_a_directive")
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestDirectiveSpec(t *testing.T) {
+	t.Parallel()
+	specs, ok := OssLatestRegistry().Lookup("access_log")
+	require.True(t, ok)
+	require.Len(t, specs, 2)
+	require.Equal(t, DirectiveSpec{
+		Name:     "access_log",
+		Contexts: ContextHTTP | ContextHTTPServer | ContextHTTPLocation | ContextHTTPLocationIf | ContextHTTPLimitExcept,
+		Args:     Args1OrMore,
+		Module:   "nginx",
+	}, specs[0])
+	require.True(t, specs[1].Contexts.Has(ContextStreamServer))
+	require.False(t, specs[1].Contexts.Has(ContextHTTPServer))
+
+	specs, _ = NjsLatestRegistry().Lookup("js_body_filter")
+	require.Equal(t, "njs", specs[0].Module)
+
+	specs, _ = OssLatestRegistry().Lookup("upstream")
+	require.True(t, specs[0].Block)
+	require.Equal(t, uint(ngxHTTPMainConf|ngxConfBlock|ngxConfTake1), specs[0].Mask())
+
+	// the tables tell which directives NGINX rejects when repeated
+	specs, _ = OssLatestRegistry().Lookup("sendfile")
+	require.True(t, specs[0].Unique)
+	require.Equal(t, ArgsFlag, specs[0].Args)
+	require.Equal(t, uint(ngxHTTPMainConf|ngxHTTPSrvConf|ngxHTTPLocConf|ngxHTTPLifConf|ngxConfFlag|ngxConfUnique), specs[0].Mask())
+
+	mask, ok := ContextMaskOf([]string{"http", "location", "if"})
+	require.True(t, ok)
+	require.Equal(t, ContextHTTPLocationIf, mask)
+	_, ok = ContextMaskOf([]string{"nope"})
+	require.False(t, ok)
+}
+
+func TestCustomContext(t *testing.T) {
+	t.Parallel()
+	if bits.UintSize < 64 {
+		require.PanicsWithValue(t, "crossplane: CustomContext needs a 64-bit uint", func() { CustomContext(0) })
+		return
+	}
+	var all ContextMask
+	for n := 0; n < 32; n++ {
+		c := CustomContext(n)
+		require.NotZero(t, c)
+		require.False(t, all.Has(c))
+		require.False(t, (ContextAny | ContextMgmt | ngxDirectConf).Has(c))
+		all |= c
+	}
+	require.PanicsWithValue(t, "crossplane: CustomContext(32) is out of range, n must be from 0 to 31", func() { CustomContext(32) })
+	require.PanicsWithValue(t, "crossplane: CustomContext(-1) is out of range, n must be from 0 to 31", func() { CustomContext(-1) })
+}
+
+func TestArgSpecAllows(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		args    ArgSpec
+		allowed []int
+	}{
+		{ArgsNone, []int{0}},
+		{Args12, []int{1, 2}},
+		{Args1234, []int{1, 2, 3, 4}},
+		{ArgsFlag, []int{1}},
+		{ArgsAny, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{Args1OrMore, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{Args2OrMore, []int{2, 3, 4, 5, 6, 7, 8, 9}},
+		{ArgsExpr | Args1OrMore, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+	} {
+		var allowed []int
+		for n := 0; n < 10; n++ {
+			if tc.args.Allows(n) {
+				allowed = append(allowed, n)
+			}
+		}
+		require.Equal(t, tc.allowed, allowed, "%#x", tc.args)
+	}
+}
+
+func TestRegistryCustomModule(t *testing.T) {
+	t.Parallel()
+	custom := NewRegistry(
+		DirectiveSpec{Name: "my_block", Contexts: ContextHTTP | ContextHTTPServer, Block: true, Args: ArgsNone, Module: "my"},
+		DirectiveSpec{Name: "my_flag", Contexts: ContextAny, Args: ArgsFlag, Module: "my"},
+	)
+	registry := ComposeRegistries(OssLatestRegistry(), custom)
+	require.Equal(t, OssLatestRegistry().Len()+2, registry.Len())
+
+	config := "http {\n    my_block {\n        my_flag on;\n    }\n    my_flag maybe;\n}\nmy_block {}\n"
+	payload, err := Parse("nginx.conf", &ParseOptions{
+		Open:             func(string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(config)), nil },
+		DirectiveSources: []MatchFunc{registry.MatchFunc()},
+	})
+	require.NoError(t, err)
+	var errs []string
+	for _, e := range payload.Errors {
+		errs = append(errs, e.Error.Error())
+	}
+	require.Equal(t, []string{
+		`invalid value "maybe" in "my_flag" directive, it must be "on" or "off" in nginx.conf:5`,
+		`"my_block" directive is not allowed here in nginx.conf:7`,
+	}, errs)
+
+	streamServer := registry.Filter(func(s DirectiveSpec) bool {
+		return s.Contexts.Has(ContextStreamServer) || s.Module == "my"
+	})
+	_, ok := streamServer.Lookup("http")
+	require.False(t, ok)
+	_, ok = streamServer.Lookup("proxy_pass")
+	require.True(t, ok)
+	_, ok = streamServer.Lookup("my_flag")
+	require.True(t, ok)
+	require.Equal(t, streamServer.Names()[0], streamServer.Specs()[0].Name)
+
+	// equal specs are only added once
+	custom.Add(DirectiveSpec{Name: "my_flag", Contexts: ContextAny, Args: ArgsFlag, Module: "my"})
+	specs, _ := custom.Lookup("my_flag")
+	require.Len(t, specs, 1)
+}
@@ -23,7 +23,7 @@ If the command line is long, we recommend using json config(--config-path).
 If you don't provide --path, we will use the whole repository at provided url by default.
 
 usage: $(basename "$0") [-b|--branch] [-c|--config-path] [-d|--directive-map-name]
- [-mn|--match-func-name] [-f|--filter] [-o | --override] [-mc|--match-func-comment] [-p|--path] [--url] [-h|--help]
+ [-mn|--match-func-name] [-f|--filter] [-o | --override] [-D|--define] [-U|--undefine] [-mc|--match-func-comment] [-p|--path] [--url] [-h|--help]
     -h  | --help                Display this message
 
     -b  | --branch              Branch to checkout, defaults to "$branch". (optional)
@@ -50,6 +50,14 @@ usage: $(basename "$0") [-b|--branch] [-c|--config-path] [-d|--directive-map-nam
      You can provide it here or through json config(--config-path). You can provide it multiple times for different directives.
      If this is provided, the override in json config will be ignored. (optional)
     
+    -D  | --define              A feature macro nginx was configured with, as NAME or NAME=VALUE. An example is: --define NGX_HTTP_SSL --define NGX_HTTP_V3.
+     Directives excluded by #if, #ifdef and #ifndef with these macros are not generated. Without any, all directives are generated.
+     Conditionals with macros that are neither defined nor undefined keep their directives.
+     If this is provided, the defines in json config will be ignored. (optional)
+
+    -U  | --undefine            A feature macro nginx was not configured with. An example is: --undefine NGX_HTTP_V3.
+     If this is provided, the undefines in json config will be ignored. (optional)
+
     -mc | --match-func-comment  The code comment for generated matchFunc.
 	 You can add some explanations like which modules included in it. Normally it should start with match-func-name.
 	 If this is provided, the matchFuncComment in json config will be ignored. (optional)
@@ -90,6 +98,14 @@ while [ ! $# -eq 0 ]; do
             genArgs+=("-override=\"$2\"")
             shift
             ;;
+        --define | -D)
+            genArgs+=("--define=$2")
+            shift
+            ;;
+        --undefine | -U)
+            genArgs+=("--undefine=$2")
+            shift
+            ;;
         --match-func-comment | --mc)
             genArgs+=("--match-func-comment=\"$2\"")
             shift
@@ -0,0 +1,397 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpecFile is the format of the directive spec files read by LoadRegistry, in JSON or
+// YAML. For example:
+//
+//	module: my_module
+//	directives:
+//	  - name: my_zone
+//	    contexts: [http>server]
+//	    args: [take1]
+//	    block: true
+//	  - name: my_size
+//	    contexts: [http, http>server, http>location]
+//	    args: [take1, take2]
+//	    deprecations:
+//	      - deprecatedIn: "2.0"
+//	        replacement: my_zone
+//
+// Contexts are "main", "any" or the predefined block contexts joined with ">", like
+// "http>location>if". See ContextMaskOf for the list. "direct" is NGX_DIRECT_CONF and
+// "custom0" to "custom31" are the masks of CustomContext, which are unknown where uint
+// has 32 bits. Args are the NGINX argument styles "noargs", "take1" to "take7", "take12",
+// "take13", "take23", "take34", "take123", "take1234", "flag", "any", "1more", "2more"
+// and "expr".
+//
+// A directive with different arguments in different contexts has an entry for each.
+type SpecFile struct {
+	// Module is the Module of the entries that don't set their own.
+	Module     string          `json:"module,omitempty" yaml:"module,omitempty"`
+	Directives []SpecFileEntry `json:"directives" yaml:"directives"`
+}
+
+// SpecFileEntry is a DirectiveSpec in a SpecFile.
+type SpecFileEntry struct {
+	Name       string   `json:"name" yaml:"name"`
+	Contexts   []string `json:"contexts" yaml:"contexts"`
+	Args       []string `json:"args" yaml:"args"`
+	Block      bool     `json:"block,omitempty" yaml:"block,omitempty"`
+	Module     string   `json:"module,omitempty" yaml:"module,omitempty"`
+	MinVersion string   `json:"minVersion,omitempty" yaml:"minVersion,omitempty"`
+	MaxVersion string   `json:"maxVersion,omitempty" yaml:"maxVersion,omitempty"`
+
+	// Unique is the Unique of the DirectiveSpec. The spec is also Unique if ArgType
+	// isn't Repeatable.
+	Unique bool `json:"unique,omitempty" yaml:"unique,omitempty"`
+
+	// Deprecations are the Deprecations of the DirectiveSpec.
+	Deprecations []SpecFileDeprecation `json:"deprecations,omitempty" yaml:"deprecations,omitempty"`
+
+	// ArgType describes the values of the arguments, if known. It isn't part of the
+	// Registry, use SpecFile.ArgTypes to get it.
+	ArgType *ArgType `json:"argType,omitempty" yaml:"argType,omitempty"`
+}
+
+// SpecFileDeprecation is a Deprecation in a SpecFileEntry. It is about the directive of
+// the entry, in its contexts, and its versions are those of its module.
+type SpecFileDeprecation struct {
+	Arg          string `json:"arg,omitempty" yaml:"arg,omitempty"`
+	DeprecatedIn string `json:"deprecatedIn,omitempty" yaml:"deprecatedIn,omitempty"`
+	RemovedIn    string `json:"removedIn,omitempty" yaml:"removedIn,omitempty"`
+	Replacement  string `json:"replacement,omitempty" yaml:"replacement,omitempty"`
+	Hint         string `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+// the names of the bits of a mask in spec files, in the order they are written
+//
+//nolint:gochecknoglobals
+var (
+	specContextNames = func() []maskName {
+		names := make([]maskName, 0, len(defaultBlockContexts)+1)
+		for _, c := range defaultBlockContexts {
+			name := blockCtx(c.Path).key()
+			if name == "" {
+				name = "main"
+			}
+			names = append(names, maskName{name, uint(c.Mask)})
+		}
+		return append(names, maskName{"direct", ngxDirectConf})
+	}()
+
+	specArgNames = []maskName{
+		{"noargs", ngxConfNoArgs},
+		{"take1", ngxConfTake1},
+		{"take2", ngxConfTake2},
+		{"take3", ngxConfTake3},
+		{"take4", ngxConfTake4},
+		{"take5", ngxConfTake5},
+		{"take6", ngxConfTake6},
+		{"take7", ngxConfTake6 << 1},
+		{"flag", ngxConfFlag},
+		{"any", ngxConfAny},
+		{"1more", ngxConf1More},
+		{"2more", ngxConf2More},
+		{"expr", ngxConfExpr},
+	}
+
+	// names that are read but not written
+	specArgAliases = map[string]uint{
+		"take12":   ngxConfTake12,
+		"take13":   ngxConfTake13,
+		"take23":   ngxConfTake23,
+		"take34":   ngxConfTake34,
+		"take123":  ngxConfTake123,
+		"take1234": ngxConfTake1234,
+	}
+)
+
+type maskName struct {
+	name string
+	mask uint
+}
+
+const customContextPrefix = "custom"
+
+func parseContextName(name string) (ContextMask, bool) {
+	if name == "any" {
+		return ContextAny, true
+	}
+	for _, n := range specContextNames {
+		if n.name == name {
+			return ContextMask(n.mask), true
+		}
+	}
+	if strings.HasPrefix(name, customContextPrefix) {
+		n, err := strconv.Atoi(strings.TrimPrefix(name, customContextPrefix))
+		if err == nil && n >= 0 && n < customContexts && bits.UintSize == 64 {
+			return CustomContext(n), true
+		}
+	}
+	return 0, false
+}
+
+func parseArgName(name string) (ArgSpec, bool) {
+	if mask, ok := specArgAliases[name]; ok {
+		return ArgSpec(mask), true
+	}
+	for _, n := range specArgNames {
+		if n.name == name {
+			return ArgSpec(n.mask), true
+		}
+	}
+	return 0, false
+}
+
+func contextNames(mask ContextMask) []string {
+	names := []string{}
+	if mask.Has(ContextAny) {
+		names = append(names, "any")
+		mask &^= ContextAny
+	}
+	for _, n := range specContextNames {
+		if uint(mask)&n.mask != 0 {
+			names = append(names, n.name)
+		}
+	}
+	for i := 0; i < customContexts && bits.UintSize == 64; i++ {
+		if c := CustomContext(i); mask.Has(c) {
+			names = append(names, customContextPrefix+strconv.Itoa(i))
+		}
+	}
+	return names
+}
+
+func argNames(args ArgSpec) []string {
+	names := []string{}
+	for _, n := range specArgNames {
+		if uint(args)&n.mask != 0 {
+			names = append(names, n.name)
+		}
+	}
+	return names
+}
+
+// the masks by the names of their constants, which generated code and the overrides of
+// the generator use
+//
+//nolint:gochecknoglobals
+var maskConstants = map[string]uint{
+	"ngxDirectConf":     ngxDirectConf,
+	"ngxMainConf":       ngxMainConf,
+	"ngxEventConf":      ngxEventConf,
+	"ngxMailMainConf":   ngxMailMainConf,
+	"ngxMailSrvConf":    ngxMailSrvConf,
+	"ngxStreamMainConf": ngxStreamMainConf,
+	"ngxStreamSrvConf":  ngxStreamSrvConf,
+	"ngxStreamUpsConf":  ngxStreamUpsConf,
+	"ngxHTTPMainConf":   ngxHTTPMainConf,
+	"ngxHTTPSrvConf":    ngxHTTPSrvConf,
+	"ngxHTTPLocConf":    ngxHTTPLocConf,
+	"ngxHTTPUpsConf":    ngxHTTPUpsConf,
+	"ngxHTTPSifConf":    ngxHTTPSifConf,
+	"ngxHTTPLifConf":    ngxHTTPLifConf,
+	"ngxHTTPLmtConf":    ngxHTTPLmtConf,
+	"ngxMgmtMainConf":   ngxMgmtMainConf,
+	"ngxAnyConf":        ngxAnyConf,
+	"ngxConfNoArgs":     ngxConfNoArgs,
+	"ngxConfTake1":      ngxConfTake1,
+	"ngxConfTake2":      ngxConfTake2,
+	"ngxConfTake3":      ngxConfTake3,
+	"ngxConfTake4":      ngxConfTake4,
+	"ngxConfTake5":      ngxConfTake5,
+	"ngxConfTake6":      ngxConfTake6,
+	"ngxConfTake7":      ngxConfTake6 << 1,
+	"ngxConfTake12":     ngxConfTake12,
+	"ngxConfTake13":     ngxConfTake13,
+	"ngxConfTake23":     ngxConfTake23,
+	"ngxConfTake34":     ngxConfTake34,
+	"ngxConfTake123":    ngxConfTake123,
+	"ngxConfTake1234":   ngxConfTake1234,
+	"ngxConfFlag":       ngxConfFlag,
+	"ngxConfAny":        ngxConfAny,
+	"ngxConf1More":      ngxConf1More,
+	"ngxConf2More":      ngxConf2More,
+	"ngxConfExpr":       ngxConfExpr,
+}
+
+// SpecFileMaskName returns the name that spec files give to a mask constant, like
+// "http>server" for ngxHTTPSrvConf or "take12" for ngxConfTake12, and whether it is a
+// context rather than an argument style. It returns false for ngxConfBlock and
+// ngxConfUnique, which are the block and unique fields of SpecFileEntry, and for unknown
+// constants.
+func SpecFileMaskName(constant string) (name string, context bool, ok bool) {
+	mask, ok := maskConstants[constant]
+	if !ok {
+		return "", false, false
+	}
+	if mask&argBits == 0 {
+		if mask == ngxAnyConf {
+			return "any", true, true
+		}
+		for _, n := range specContextNames {
+			if n.mask == mask {
+				return n.name, true, true
+			}
+		}
+		return "", false, false
+	}
+	for alias, m := range specArgAliases {
+		if m == mask {
+			return alias, false, true
+		}
+	}
+	for _, n := range specArgNames {
+		if n.mask == mask {
+			return n.name, false, true
+		}
+	}
+	return "", false, false
+}
+
+// Registry returns a Registry with the directives of f.
+func (f *SpecFile) Registry() (*Registry, error) {
+	r := NewRegistry()
+	for i, entry := range f.Directives {
+		if entry.Name == "" {
+			return nil, fmt.Errorf("directive %d has no name", i)
+		}
+		spec := DirectiveSpec{
+			Name:       entry.Name,
+			Block:      entry.Block,
+			Module:     entry.Module,
+			MinVersion: entry.MinVersion,
+			MaxVersion: entry.MaxVersion,
+			Unique:     entry.Unique || (entry.ArgType != nil && !entry.ArgType.Repeatable()),
+		}
+		if spec.Module == "" {
+			spec.Module = f.Module
+		}
+		for _, name := range entry.Contexts {
+			mask, ok := parseContextName(name)
+			if !ok {
+				return nil, fmt.Errorf(`directive "%s" has unknown context "%s"`, entry.Name, name)
+			}
+			spec.Contexts |= mask
+		}
+		for _, name := range entry.Args {
+			mask, ok := parseArgName(name)
+			if !ok {
+				return nil, fmt.Errorf(`directive "%s" has unknown argument style "%s"`, entry.Name, name)
+			}
+			spec.Args |= mask
+		}
+		for _, d := range entry.Deprecations {
+			spec.Deprecations = append(spec.Deprecations, Deprecation{
+				Arg:          d.Arg,
+				DeprecatedIn: d.DeprecatedIn,
+				RemovedIn:    d.RemovedIn,
+				Replacement:  d.Replacement,
+				Hint:         d.Hint,
+			})
+		}
+		r.Add(spec)
+	}
+	return r, nil
+}
+
+// ArgTypes returns the arg types of the entries of f that have one, by directive name.
+func (f *SpecFile) ArgTypes() map[string][]ArgType {
+	argTypes := map[string][]ArgType{}
+	for _, entry := range f.Directives {
+		if entry.ArgType != nil {
+			argTypes[entry.Name] = append(argTypes[entry.Name], *entry.ArgType)
+		}
+	}
+	return argTypes
+}
+
+// SpecFile returns the directives of r in a SpecFile, sorted by name.
+func (r *Registry) SpecFile() *SpecFile {
+	f := &SpecFile{Directives: []SpecFileEntry{}}
+	for _, spec := range r.Specs() {
+		entry := SpecFileEntry{
+			Name:       spec.Name,
+			Contexts:   contextNames(spec.Contexts),
+			Args:       argNames(spec.Args),
+			Block:      spec.Block,
+			Module:     spec.Module,
+			MinVersion: spec.MinVersion,
+			MaxVersion: spec.MaxVersion,
+			Unique:     spec.Unique,
+		}
+		for _, d := range spec.Deprecations {
+			entry.Deprecations = append(entry.Deprecations, SpecFileDeprecation{
+				Arg:          d.Arg,
+				DeprecatedIn: d.DeprecatedIn,
+				RemovedIn:    d.RemovedIn,
+				Replacement:  d.Replacement,
+				Hint:         d.Hint,
+			})
+		}
+		f.Directives = append(f.Directives, entry)
+	}
+	return f
+}
+
+// LoadRegistry reads a SpecFile in JSON or YAML from r and returns a Registry with its
+// directives. Use its MatchFunc in ParseOptions.DirectiveSources to parse configs that
+// use the directives.
+func LoadRegistry(r io.Reader) (*Registry, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var f SpecFile
+	if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 && trimmed[0] == '{' {
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&f)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		dec.KnownFields(true)
+		if err = dec.Decode(&f); errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading directive specs: %w", err)
+	}
+	return f.Registry()
+}
+
+// LoadRegistryFile reads the SpecFile at path like LoadRegistry.
+func LoadRegistryFile(path string) (*Registry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := LoadRegistry(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
+}
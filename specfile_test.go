@@ -0,0 +1,148 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadRegistryFile(t *testing.T) {
+	t.Parallel()
+	registry, err := LoadRegistryFile(filepath.Join("testdata", "specs", "my_module.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, []DirectiveSpec{
+		{Name: "my_enabled", Contexts: ContextHTTP | ContextHTTPServer | ContextHTTPLocation, Args: ArgsFlag, Module: "my_module", MinVersion: "1.2"},
+		{Name: "my_size", Contexts: CustomContext(0), Args: Args12, Module: "my_module"},
+		{Name: "my_zone", Contexts: ContextHTTPServer, Args: Args1, Block: true, Module: "my_module"},
+	}, registry.Specs())
+
+	config := "http {\n    server {\n        my_zone a {\n            my_size 1 2 3;\n        }\n        my_enabled on;\n    }\n}\n"
+	payload, err := Parse("nginx.conf", &ParseOptions{
+		Open:             func(string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(config)), nil },
+		DirectiveSources: []MatchFunc{MatchNginxPlusLatest, registry.MatchFunc()},
+		BlockContexts:    []BlockContext{{Path: []string{"http", "server", "my_zone"}, Mask: CustomContext(0)}},
+	})
+	require.NoError(t, err)
+	require.Len(t, payload.Errors, 1)
+	require.EqualError(t, payload.Errors[0].Error, `invalid number of arguments in "my_size" directive in nginx.conf:4`)
+
+	_, err = LoadRegistryFile(filepath.Join("testdata", "specs", "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestSpecFileRoundTrip(t *testing.T) {
+	t.Parallel()
+	for name, registry := range map[string]*Registry{
+		"oss":     OssLatestRegistry(),
+		"default": DefaultRegistry(),
+		"lua":     LuaLatestRegistry(),
+		"custom": NewRegistry(DirectiveSpec{
+			Name: "my_directive", Contexts: ContextHTTP | CustomContext(3), Args: ArgsExpr | Args1OrMore, Block: true,
+		}),
+	} {
+		registry := registry
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			b, err := json.Marshal(registry.SpecFile())
+			require.NoError(t, err)
+			fromJSON, err := LoadRegistry(bytes.NewReader(b))
+			require.NoError(t, err)
+			require.Equal(t, registry.Specs(), fromJSON.Specs())
+
+			b, err = yaml.Marshal(registry.SpecFile())
+			require.NoError(t, err)
+			fromYAML, err := LoadRegistry(bytes.NewReader(b))
+			require.NoError(t, err)
+			require.Equal(t, registry.Specs(), fromYAML.Specs())
+		})
+	}
+}
+
+func TestSpecFileAny(t *testing.T) {
+	t.Parallel()
+	f := NewRegistry(DirectiveSpec{Name: "a", Contexts: ContextAny | ContextMgmt, Args: ArgsNone}).SpecFile()
+	require.Equal(t, []string{"any", "mgmt"}, f.Directives[0].Contexts)
+	require.Equal(t, []string{"noargs"}, f.Directives[0].Args)
+}
+
+func TestLoadRegistryInvalid(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		spec string
+		err  string
+	}{
+		{"", ""},
+		{`{"directives": []}`, ""},
+		{`{"directives": [{"name": "a", "contexts": ["http"], "args": ["take1"], "extra": 1}]}`,
+			`reading directive specs: json: unknown field "extra"`},
+		{"directives:\n  - name: a\n    context: [http]\n",
+			"reading directive specs: yaml: unmarshal errors:\n  line 3: field context not found in type crossplane.SpecFileEntry"},
+		{"directives:\n  - contexts: [http]\n    args: [take1]\n", "directive 0 has no name"},
+		{"directives:\n  - name: a\n    contexts: [htp]\n    args: [take1]\n", `directive "a" has unknown context "htp"`},
+		{"directives:\n  - name: a\n    contexts: [custom32]\n    args: [take1]\n", `directive "a" has unknown context "custom32"`},
+		{"directives:\n  - name: a\n    contexts: [http]\n    args: [take8]\n", `directive "a" has unknown argument style "take8"`},
+	} {
+		_, err := LoadRegistry(strings.NewReader(tc.spec))
+		if tc.err == "" {
+			require.NoError(t, err, tc.spec)
+		} else {
+			require.EqualError(t, err, tc.err, tc.spec)
+		}
+	}
+}
+
+func TestSpecFileMaskName(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		constant string
+		name     string
+		context  bool
+		ok       bool
+	}{
+		{"ngxHTTPSrvConf", "http>server", true, true},
+		{"ngxMainConf", "main", true, true},
+		{"ngxAnyConf", "any", true, true},
+		{"ngxMgmtMainConf", "mgmt", true, true},
+		{"ngxConfTake1", "take1", false, true},
+		{"ngxConfTake12", "take12", false, true},
+		{"ngxConfTake7", "take7", false, true},
+		{"ngxConfAny", "any", false, true},
+		{"ngxConfBlock", "", false, false},
+		{"ngxUnknownConf", "", false, false},
+	} {
+		name, context, ok := SpecFileMaskName(tc.constant)
+		require.Equal(t, tc.name, name, tc.constant)
+		require.Equal(t, tc.context, context, tc.constant)
+		require.Equal(t, tc.ok, ok, tc.constant)
+	}
+
+	// every name is read back as the mask of the constant
+	for constant, mask := range maskConstants {
+		name, context, ok := SpecFileMaskName(constant)
+		if !ok {
+			continue
+		}
+		if context {
+			m, found := parseContextName(name)
+			require.True(t, found, constant)
+			require.Equal(t, mask, uint(m), constant)
+		} else {
+			m, found := parseArgName(name)
+			require.True(t, found, constant)
+			require.Equal(t, mask, uint(m), constant)
+		}
+	}
+}
@@ -16,6 +16,10 @@ type Payload struct {
 	Status string         `json:"status"`
 	Errors []PayloadError `json:"errors"`
 	Config []Config       `json:"config"`
+
+	// IncludeGraph is set by Parse and describes which files include each other.
+	// It is not part of the JSON form of the Payload, use IncludeGraph.WriteJSON instead.
+	IncludeGraph *IncludeGraph `json:"-"`
 }
 
 type PayloadError struct {
@@ -45,9 +49,36 @@ type Directive struct {
 	Includes  []int      `json:"includes,omitempty"`
 	Block     Directives `json:"block,omitempty"`
 	Comment   *string    `json:"comment,omitempty"`
+
+	// The fields below are set when ParseOptions.AttachComments is true.
+
+	// LeadingComments are the comments on the lines before the directive.
+	LeadingComments []string `json:"leadingComments,omitempty"`
+	// TrailingComment is the comment after the ";" or "{" that ends the directive.
+	TrailingComment *string `json:"trailingComment,omitempty"`
+	// ArgComments are the comments between the arguments of the directive.
+	ArgComments []ArgComment `json:"argComments,omitempty"`
+
+	// The fields below are set when ParseOptions.KeepFormatting is true.
+
+	// BlankLinesBefore is the number of blank lines before the directive, or before its
+	// first leading comment.
+	BlankLinesBefore int `json:"blankLinesBefore,omitempty"`
+	// LeadingCommentBlankLines holds the number of blank lines after each of the
+	// LeadingComments. It is only set if one of them is followed by blank lines.
+	LeadingCommentBlankLines []int `json:"leadingCommentBlankLines,omitempty"`
+	// ArgBreaks holds the indexes of the arguments that start on a new line.
+	ArgBreaks []int `json:"argBreaks,omitempty"`
 }
 type Directives []*Directive
 
+// ArgComment is a comment found between the arguments of a directive.
+type ArgComment struct {
+	// Index is the number of arguments that come before the comment.
+	Index   int    `json:"index" yaml:"index" toml:"index"`
+	Comment string `json:"comment" yaml:"comment" toml:"comment"`
+}
+
 // IsBlock returns true if this is a block directive.
 func (d Directive) IsBlock() bool {
 	return d.Block != nil
@@ -106,6 +137,18 @@ func (d *Directive) Equal(a *Directive) bool {
 		return false
 	case !strPtrEqual(a.Comment, d.Comment):
 		return false
+	case !equals(a.LeadingComments, d.LeadingComments):
+		return false
+	case !strPtrEqual(a.TrailingComment, d.TrailingComment):
+		return false
+	case len(a.ArgComments) != len(d.ArgComments):
+		return false
+	case a.BlankLinesBefore != d.BlankLinesBefore:
+		return false
+	case len(a.ArgBreaks) != len(d.ArgBreaks):
+		return false
+	case len(a.LeadingCommentBlankLines) != len(d.LeadingCommentBlankLines):
+		return false
 	case a.Line != d.Line:
 		return false
 	case a.File != d.File:
@@ -116,6 +159,21 @@ func (d *Directive) Equal(a *Directive) bool {
 			return false
 		}
 	}
+	for i, b := range a.ArgBreaks {
+		if b != d.ArgBreaks[i] {
+			return false
+		}
+	}
+	for i, b := range a.LeadingCommentBlankLines {
+		if b != d.LeadingCommentBlankLines[i] {
+			return false
+		}
+	}
+	for i, c := range a.ArgComments {
+		if c != d.ArgComments[i] {
+			return false
+		}
+	}
 	for i, dir := range a.Block {
 		if !dir.Equal(d.Block[i]) {
 			return false
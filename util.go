@@ -80,6 +80,10 @@ func prepareIfArgs(d *Directive) *Directive {
 		if len(d.Args[e]) == 0 {
 			e--
 		}
+		// "()" is a single argument that is both the first and the last
+		if b > e+1 {
+			b = e + 1
+		}
 		d.Args = d.Args[b : e+1]
 	}
 	return d
@@ -123,9 +127,10 @@ func combineConfigs(old *Payload) (*Payload, error) {
 	}
 
 	return &Payload{
-		Status: status,
-		Errors: errors,
-		Config: []Config{combined},
+		Status:       status,
+		Errors:       errors,
+		Config:       []Config{combined},
+		IncludeGraph: old.IncludeGraph,
 	}, nil
 }
 
@@ -0,0 +1,138 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+// Validate checks the directives of a payload that wasn't produced by Parse, like one
+// decoded from JSON or YAML, with the same context and argument checks Parse uses.
+// Files are checked in the block context they are included into, starting at the first
+// Config. The options select the directive sources and checks just like for Parse.
+//
+// Errors are added to the payload and its configs, unless they already have them, like
+// a decoded payload has the errors of the parse it came from. If
+// options.StopParsingOnError is true, Validate returns the first new error instead.
+func Validate(payload *Payload, options *ParseOptions) error {
+	v := &validator{payload: payload, options: options}
+	return newPayloadWalker(payload, options.BlockContexts, true).walk(v.validate)
+}
+
+type validator struct {
+	payload *Payload
+	options *ParseOptions
+}
+
+func (v *validator) handleError(cfg int, err error) error {
+	if v.hasError(cfg, err) {
+		return nil
+	}
+	if v.options.StopParsingOnError {
+		return err
+	}
+	v.payload.addError(&v.payload.Config[cfg], err, v.options)
+	return nil
+}
+
+// hasError reports whether config cfg already has err, with the same line and message.
+func (v *validator) hasError(cfg int, err error) bool {
+	var line *int
+	if e, ok := err.(*ParseError); ok {
+		line = e.Line
+	}
+	for _, e := range v.payload.Config[cfg].Errors {
+		if e.Error != nil && e.Error.Error() == err.Error() && equalLines(e.Line, line) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalLines(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// validate checks a directive, and the entries of its body if it is a map-like block.
+// The files it includes and its block aren't checked if it isn't valid.
+func (v *validator) validate(site *walkSite) error {
+	stmt := site.stmt
+	if contains(v.options.IgnoreDirectives, stmt.Directive) {
+		return errSkipDirective
+	}
+
+	term := ";"
+	if stmt.IsBlock() {
+		term = "{"
+	}
+	if err := analyze(site.file, unprepareIfArgs(stmt), term, site.ctx, v.options); err != nil {
+		if err := v.handleError(site.cfg, err); err != nil {
+			return err
+		}
+		return errSkipDirective
+	}
+
+	if _, ok := mapBodies[stmt.Directive]; ok && stmt.IsBlock() {
+		return v.validateMapBody(site.cfg, site.file, stmt.Block, stmt.Directive)
+	}
+	return nil
+}
+
+// validateMapBody checks the entries of a map-like block by its own rules.
+func (v *validator) validateMapBody(cfg int, file string, block Directives, name string) error {
+	for _, entry := range block {
+		if entry.IsComment() {
+			continue
+		}
+		term := ";"
+		if entry.IsBlock() {
+			term = "{"
+		}
+		if err := analyzeMapBody(file, entry, term, name); err != nil {
+			if err := v.handleError(cfg, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unprepareIfArgs returns a copy of an `if` directive with the parentheses that Parse
+// removed from its arguments, so that it can be analyzed again.
+func unprepareIfArgs(d *Directive) *Directive {
+	if d.Directive != "if" || len(d.Args) == 0 {
+		return d
+	}
+	c := *d
+	c.Args = append([]string{}, d.Args...)
+	c.Args[0] = "(" + c.Args[0]
+	c.Args[len(c.Args)-1] += ")"
+	return &c
+}
+
+// ValidateDirectives checks a list of directives that belong in the block given by ctx,
+// like []string{"http", "server"} for the directives of a server, and returns every error
+// found. The file is only used in the errors.
+func ValidateDirectives(file string, directives Directives, ctx []string, options *ParseOptions) []error {
+	opts := *options
+	opts.StopParsingOnError = false
+	payload := &Payload{Config: []Config{{File: file, Parsed: directives}}}
+	v := &validator{payload: payload, options: &opts}
+	name := blockCtx(ctx).getLastBlock()
+	if _, ok := mapBodies[name]; ok {
+		_ = v.validateMapBody(0, file, directives, name)
+	} else {
+		w := newPayloadWalker(payload, opts.BlockContexts, true)
+		_ = w.walkConfig(0, 0, append(blockCtx{}, ctx...), nil, v.validate)
+	}
+
+	var errs []error
+	for _, e := range payload.Errors {
+		errs = append(errs, e.Error)
+	}
+	return errs
+}
@@ -0,0 +1,312 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Product is an NGINX distribution whose directives are known for several versions.
+type Product string
+
+const (
+	ProductOSS  Product = "nginx"
+	ProductPlus Product = "nginx-plus"
+)
+
+// versionLatest is the version of the tables generated from the latest sources.
+const versionLatest = "latest"
+
+type productVersion struct {
+	version    string
+	directives map[string][]uint
+}
+
+// the directive tables of each product, from the oldest version to the latest
+//
+//nolint:gochecknoglobals
+var productVersions = map[Product][]productVersion{
+	ProductOSS: {
+		{"1.24", oss124Directives},
+		{"1.26", oss126Directives},
+		{versionLatest, ossLatestDirectives},
+	},
+	ProductPlus: {
+		{"R30", nginxPlusR30Directives},
+		{"R31", nginxPlusR31Directives},
+		{versionLatest, nginxPlusLatestDirectives},
+	},
+}
+
+// Versions returns the versions of product whose directives are known, from the oldest to
+// the latest. The last one is "latest", for the latest sources of the product.
+func Versions(product Product) []string {
+	versions := make([]string, 0, len(productVersions[product]))
+	for _, v := range productVersions[product] {
+		versions = append(versions, v.version)
+	}
+	return versions
+}
+
+// DirectiveHistory is how a directive changed across the known versions of a product.
+type DirectiveHistory struct {
+	Name    string
+	Product Product
+
+	// FirstVersion and LastVersion are the first and the last known versions that have
+	// the directive. If the directive was removed and added back, the versions in between
+	// are in Changes.
+	FirstVersion string
+	LastVersion  string
+
+	// Changes holds the specs of the directive in FirstVersion and in every later version
+	// where they changed. The specs of a version where the directive was removed are empty.
+	Changes []DirectiveChange
+}
+
+// DirectiveChange holds the specs of a directive from Version on.
+type DirectiveChange struct {
+	Version string
+	Specs   []DirectiveSpec
+}
+
+// DirectiveHistories returns the history of every directive of product, by name.
+func DirectiveHistories(product Product) map[string]*DirectiveHistory {
+	histories := map[string]*DirectiveHistory{}
+	versions := productVersions[product]
+	for i, v := range versions {
+		for name, masks := range v.directives {
+			h, ok := histories[name]
+			if !ok {
+				h = &DirectiveHistory{Name: name, Product: product, FirstVersion: v.version}
+				histories[name] = h
+			}
+			h.LastVersion = v.version
+			if last := h.Changes; len(last) == 0 || !equalMasks(last[len(last)-1].Specs, masks) {
+				h.Changes = append(h.Changes, DirectiveChange{Version: v.version, Specs: versionSpecs(name, product, masks)})
+			}
+		}
+		// record the removal of the directives that the version doesn't have
+		for name, h := range histories {
+			if _, ok := v.directives[name]; !ok && i > 0 && len(h.Changes[len(h.Changes)-1].Specs) > 0 {
+				h.Changes = append(h.Changes, DirectiveChange{Version: v.version, Specs: []DirectiveSpec{}})
+			}
+		}
+	}
+	return histories
+}
+
+// DirectiveHistoryOf returns the history of a directive of product.
+func DirectiveHistoryOf(product Product, directive string) (*DirectiveHistory, bool) {
+	h, ok := DirectiveHistories(product)[directive]
+	return h, ok
+}
+
+// HistoryRegistry returns a Registry with every spec that a directive of product ever
+// had. The MinVersion and MaxVersion of each spec are the versions it was valid in, with
+// no MaxVersion for the specs of the latest version.
+func HistoryRegistry(product Product) *Registry {
+	versions := Versions(product)
+	r := NewRegistry()
+	for _, h := range DirectiveHistories(product) {
+		for i, change := range h.Changes {
+			maxVersion := ""
+			if i+1 < len(h.Changes) {
+				maxVersion = versions[versionIndex(product, h.Changes[i+1].Version)-1]
+			} else if h.LastVersion != versionLatest {
+				maxVersion = h.LastVersion
+			}
+			for _, spec := range change.Specs {
+				spec.MinVersion = change.Version
+				spec.MaxVersion = maxVersion
+				r.Add(spec)
+			}
+		}
+	}
+	return r
+}
+
+func versionSpecs(name string, product Product, masks []uint) []DirectiveSpec {
+	specs := make([]DirectiveSpec, 0, len(masks))
+	for _, mask := range masks {
+		specs = append(specs, specFromMask(name, string(product), mask))
+	}
+	return specs
+}
+
+func equalMasks(specs []DirectiveSpec, masks []uint) bool {
+	if len(specs) != len(masks) {
+		return false
+	}
+	for i, spec := range specs {
+		if spec.Mask() != masks[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func matchTable(directives map[string][]uint) MatchFunc {
+	return func(directive string) ([]uint, bool) {
+		m, ok := directives[directive]
+		return m, ok
+	}
+}
+
+func versionIndex(product Product, version string) int {
+	for i, v := range productVersions[product] {
+		if v.version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// VersionRequirement is a directive that doesn't work before Version.
+type VersionRequirement struct {
+	File      string
+	Line      int
+	Directive string
+	BlockCtx  []string
+
+	// Version is the first version in which the directive works as it is used.
+	Version string
+
+	// Reason is the error the directive has in the version before Version, like
+	// `unknown directive "x"` or `invalid number of arguments in "x" directive`.
+	Reason string
+}
+
+// VersionReport is the result of RequiredVersion.
+type VersionReport struct {
+	Product Product
+
+	// MinVersion is the first version in which every directive of the payload works.
+	// It is empty if no known version works for all of them.
+	MinVersion string
+
+	// Requirements holds every directive that doesn't work in the oldest known version,
+	// the newest Version first, then by file and line. They are what keeps the payload
+	// from being used with an older version.
+	Requirements []VersionRequirement
+
+	// Removed holds every directive that stops working in a version after the first one
+	// it works in, with that version. The directive may work again in a later version.
+	Removed []VersionRequirement
+}
+
+// RequiredVersion reports the oldest known version of product that the payload works
+// with, and explains every directive that needs a newer version. A directive is checked
+// against the directive table of each version, in its block context, like Parse does.
+// Directives that no version of product knows, like those of third-party modules, are
+// ignored, as are the directives that don't work in any version because of errors.
+//
+// A directive can work in some versions, stop working in a later one and work again
+// after that, so the payload works with the versions that every directive works with.
+func RequiredVersion(payload *Payload, product Product) (*VersionReport, error) {
+	versions := productVersions[product]
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("unknown product %q", product)
+	}
+	return requiredVersion(payload, product, versions), nil
+}
+
+func requiredVersion(payload *Payload, product Product, versions []productVersion) *VersionReport {
+	options := make([]*ParseOptions, len(versions))
+	works := make([]bool, len(versions))
+	for i, v := range versions {
+		options[i] = &ParseOptions{
+			DirectiveSources:         []MatchFunc{matchTable(v.directives)},
+			ErrorOnUnknownDirectives: true,
+		}
+		works[i] = true
+	}
+	index := func(version string) int {
+		for i, v := range versions {
+			if v.version == version {
+				return i
+			}
+		}
+		return -1
+	}
+
+	report := &VersionReport{Product: product}
+	_ = newPayloadWalker(payload, nil, true).walk(func(site *walkSite) error {
+		file, stmt, ctx := site.file, site.stmt, site.ctx
+		term := ";"
+		if stmt.IsBlock() {
+			term = "{"
+		}
+		errs := make([]error, len(versions))
+		first := -1
+		for i := range versions {
+			errs[i] = analyze(file, unprepareIfArgs(stmt), term, ctx, options[i])
+			if errs[i] == nil && first < 0 {
+				first = i
+			}
+		}
+		if first < 0 {
+			return nil
+		}
+
+		requirement := func(i int, err error) VersionRequirement {
+			reason := err.Error()
+			if e, ok := err.(*ParseError); ok {
+				reason = e.What
+			}
+			return VersionRequirement{
+				File:      file,
+				Line:      stmt.Line,
+				Directive: stmt.Directive,
+				BlockCtx:  append([]string{}, ctx...),
+				Version:   versions[i].version,
+				Reason:    reason,
+			}
+		}
+		if first > 0 {
+			report.Requirements = append(report.Requirements, requirement(first, errs[first-1]))
+		}
+		removed := false
+		for i := range versions {
+			if errs[i] == nil {
+				continue
+			}
+			works[i] = false
+			if i > first && !removed {
+				report.Removed = append(report.Removed, requirement(i, errs[i]))
+				removed = true
+			}
+		}
+		return nil
+	})
+
+	// the payload works with the first version that every directive works with
+	for i, ok := range works {
+		if ok {
+			report.MinVersion = versions[i].version
+			break
+		}
+	}
+
+	sortRequirements := func(reqs []VersionRequirement) {
+		sort.SliceStable(reqs, func(i, j int) bool {
+			a, b := index(reqs[i].Version), index(reqs[j].Version)
+			if a != b {
+				return a > b
+			}
+			if reqs[i].File != reqs[j].File {
+				return reqs[i].File < reqs[j].File
+			}
+			return reqs[i].Line < reqs[j].Line
+		})
+	}
+	sortRequirements(report.Requirements)
+	sortRequirements(report.Removed)
+	return report
+}
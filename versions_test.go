@@ -0,0 +1,175 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectiveHistory(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, []string{"1.24", "1.26", "latest"}, Versions(ProductOSS))
+	require.Equal(t, []string{"R30", "R31", "latest"}, Versions(ProductPlus))
+
+	ssl, ok := DirectiveHistoryOf(ProductOSS, "ssl")
+	require.True(t, ok)
+	require.Equal(t, "1.24", ssl.FirstVersion)
+	require.Equal(t, "1.24", ssl.LastVersion)
+	require.Len(t, ssl.Changes, 2)
+	require.Equal(t, "1.24", ssl.Changes[0].Version)
+	require.Len(t, ssl.Changes[0].Specs, 2)
+	require.Equal(t, DirectiveChange{Version: "1.26", Specs: []DirectiveSpec{}}, ssl.Changes[1])
+
+	serverName, ok := DirectiveHistoryOf(ProductOSS, "server_name")
+	require.True(t, ok)
+	require.Equal(t, "latest", serverName.LastVersion)
+	require.Len(t, serverName.Changes, 2)
+	require.Equal(t, "1.26", serverName.Changes[1].Version)
+	require.Equal(t, DirectiveSpec{
+		Name: "server_name", Contexts: ContextStreamServer, Args: Args1OrMore, Module: "nginx",
+	}, serverName.Changes[1].Specs[2])
+
+	http2, ok := DirectiveHistoryOf(ProductOSS, "http2")
+	require.True(t, ok)
+	require.Equal(t, "1.26", http2.FirstVersion)
+	require.Len(t, http2.Changes, 1)
+
+	_, ok = DirectiveHistoryOf(ProductOSS, "usage_report")
+	require.False(t, ok)
+	usageReport, ok := DirectiveHistoryOf(ProductPlus, "usage_report")
+	require.True(t, ok)
+	require.Equal(t, "R31", usageReport.FirstVersion)
+}
+
+func TestHistoryRegistry(t *testing.T) {
+	t.Parallel()
+	r := HistoryRegistry(ProductOSS)
+	specs, ok := r.Lookup("ssl")
+	require.True(t, ok)
+	for _, spec := range specs {
+		require.Equal(t, "1.24", spec.MinVersion)
+		require.Equal(t, "1.24", spec.MaxVersion)
+	}
+
+	specs, _ = r.Lookup("server_name")
+	var ranges []string
+	for _, spec := range specs {
+		ranges = append(ranges, spec.MinVersion+"-"+spec.MaxVersion)
+	}
+	require.Equal(t, []string{"1.24-1.24", "1.24-1.24", "1.26-", "1.26-", "1.26-"}, ranges)
+
+	specs, _ = r.Lookup("http2")
+	require.Equal(t, "1.26", specs[0].MinVersion)
+	require.Empty(t, specs[0].MaxVersion)
+}
+
+func parseString(t *testing.T, config string) *Payload {
+	t.Helper()
+	payload, err := Parse("nginx.conf", &ParseOptions{
+		Open:                      func(string) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(config)), nil },
+		SkipDirectiveContextCheck: true,
+		SkipDirectiveArgsCheck:    true,
+	})
+	require.NoError(t, err)
+	return payload
+}
+
+func TestRequiredVersion(t *testing.T) {
+	t.Parallel()
+	payload := parseString(t, `http {
+    server {
+        listen 443 ssl;
+        http2 on;
+        server_name example.com;
+        my_module_directive on;
+    }
+}
+stream {
+    server {
+        listen 12345;
+        server_name example.com;
+    }
+}
+`)
+	report, err := RequiredVersion(payload, ProductOSS)
+	require.NoError(t, err)
+	require.Equal(t, &VersionReport{
+		Product:    ProductOSS,
+		MinVersion: "1.26",
+		Requirements: []VersionRequirement{
+			{
+				File: "nginx.conf", Line: 4, Directive: "http2", BlockCtx: []string{"http", "server"},
+				Version: "1.26", Reason: `unknown directive "http2"`,
+			},
+			{
+				File: "nginx.conf", Line: 12, Directive: "server_name", BlockCtx: []string{"stream", "server"},
+				Version: "1.26", Reason: `"server_name" directive is not allowed here`,
+			},
+		},
+	}, report)
+
+	// ssl was removed in 1.26, so no version has both
+	payload = parseString(t, "http {\n    server {\n        ssl on;\n        http2 on;\n    }\n}\n")
+	report, err = RequiredVersion(payload, ProductOSS)
+	require.NoError(t, err)
+	require.Empty(t, report.MinVersion)
+	require.Len(t, report.Requirements, 1)
+	require.Equal(t, []VersionRequirement{{
+		File: "nginx.conf", Line: 3, Directive: "ssl", BlockCtx: []string{"http", "server"},
+		Version: "1.26", Reason: `unknown directive "ssl"`,
+	}}, report.Removed)
+
+	payload = parseString(t, "mgmt {\n    usage_report;\n}\nhttp {\n}\n")
+	report, err = RequiredVersion(payload, ProductPlus)
+	require.NoError(t, err)
+	require.Equal(t, "R31", report.MinVersion)
+	require.Len(t, report.Requirements, 2)
+	require.Equal(t, "mgmt", report.Requirements[0].Directive)
+	require.Equal(t, "usage_report", report.Requirements[1].Directive)
+
+	report, err = RequiredVersion(parseString(t, "events {}\n"), ProductOSS)
+	require.NoError(t, err)
+	require.Equal(t, "1.24", report.MinVersion)
+	require.Empty(t, report.Requirements)
+
+	_, err = RequiredVersion(payload, "angie")
+	require.EqualError(t, err, `unknown product "angie"`)
+}
+
+func TestRequiredVersionGap(t *testing.T) {
+	t.Parallel()
+	// my_directive was removed in 2 and added back in 3
+	versions := []productVersion{
+		{"1", map[string][]uint{"http": {ngxMainConf | ngxConfBlock | ngxConfNoArgs}, "my_directive": {ngxHTTPMainConf | ngxConfTake1}}},
+		{"2", map[string][]uint{"http": {ngxMainConf | ngxConfBlock | ngxConfNoArgs}, "my_new_directive": {ngxHTTPMainConf | ngxConfTake1}}},
+		{"3", map[string][]uint{
+			"http":             {ngxMainConf | ngxConfBlock | ngxConfNoArgs},
+			"my_directive":     {ngxHTTPMainConf | ngxConfTake1},
+			"my_new_directive": {ngxHTTPMainConf | ngxConfTake1},
+		}},
+	}
+
+	report := requiredVersion(parseString(t, "http {\n    my_directive on;\n}\n"), "my_product", versions)
+	require.Equal(t, "1", report.MinVersion)
+	require.Empty(t, report.Requirements)
+	require.Equal(t, []VersionRequirement{{
+		File: "nginx.conf", Line: 2, Directive: "my_directive", BlockCtx: []string{"http"},
+		Version: "2", Reason: `unknown directive "my_directive"`,
+	}}, report.Removed)
+
+	// version 2 has my_new_directive but not my_directive
+	report = requiredVersion(parseString(t, "http {\n    my_directive on;\n    my_new_directive on;\n}\n"), "my_product", versions)
+	require.Equal(t, "3", report.MinVersion)
+	require.Len(t, report.Requirements, 1)
+	require.Equal(t, "my_new_directive", report.Requirements[0].Directive)
+	require.Len(t, report.Removed, 1)
+}
@@ -0,0 +1,151 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import "errors"
+
+// errSkipDirective is returned by a walkFunc to skip the files included by the directive
+// and its block.
+var errSkipDirective = errors.New("skip directive") //nolint:gochecknoglobals
+
+// payloadWalker walks the directives of a payload in their block context, starting at the
+// first Config. The directives of an included file are walked after the include directive,
+// in its block context, as if they were in its place. Configs that no walked directive
+// includes are walked last, in the main context.
+//
+// Comments aren't walked, and neither are the entries of map-like blocks, like those of
+// map and geo, which aren't directives.
+type payloadWalker struct {
+	payload *Payload
+
+	// blockContexts are the custom block contexts, like ParseOptions.BlockContexts.
+	blockContexts []BlockContext
+
+	// once walks a file included several times only where it is first included, which is
+	// how Parse checks it. Walkers that change the directives need it. Otherwise a file
+	// is walked at every include, except for includes that would recurse.
+	once bool
+
+	reached []bool
+	open    []bool
+}
+
+// walkSite is a directive found by a payloadWalker.
+type walkSite struct {
+	file string
+	// cfg is the index of the Config stmt is in, and root that of the Config the walk
+	// started at.
+	cfg  int
+	root int
+	stmt *Directive
+	ctx  blockCtx
+
+	// parents holds the block directives around stmt, the innermost last, across includes.
+	parents []*Directive
+
+	// stmt is (*block)[index]. A walkFunc can replace stmt in block with other directives.
+	// It then sets index to that of stmt, or to that of the last directive it added if
+	// stmt was removed and it returns errSkipDirective, and added to the number of
+	// directives it added after index, which aren't walked.
+	block *Directives
+	index int
+	added int
+}
+
+// parent returns the block directive stmt is in, or nil in the main context.
+func (s *walkSite) parent() *Directive {
+	if len(s.parents) == 0 {
+		return nil
+	}
+	return s.parents[len(s.parents)-1]
+}
+
+type walkFunc func(site *walkSite) error
+
+func newPayloadWalker(payload *Payload, blockContexts []BlockContext, once bool) *payloadWalker {
+	return &payloadWalker{
+		payload:       payload,
+		blockContexts: blockContexts,
+		once:          once,
+		reached:       make([]bool, len(payload.Config)),
+		open:          make([]bool, len(payload.Config)),
+	}
+}
+
+// walk calls fn for every directive of the payload, and stops at the first error fn
+// returns other than errSkipDirective.
+func (w *payloadWalker) walk(fn walkFunc) error {
+	for i := range w.payload.Config {
+		if w.reached[i] {
+			continue
+		}
+		if err := w.walkConfig(i, i, blockCtx{}, nil, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *payloadWalker) walkConfig(root int, cfg int, ctx blockCtx, parents []*Directive, fn walkFunc) error {
+	w.reached[cfg] = true
+	w.open[cfg] = true
+	defer func() { w.open[cfg] = false }()
+	return w.walkBlock(root, cfg, &w.payload.Config[cfg].Parsed, ctx, parents, fn)
+}
+
+//nolint:gocognit
+func (w *payloadWalker) walkBlock(root int, cfg int, block *Directives, ctx blockCtx, parents []*Directive, fn walkFunc) error {
+	if len(ctx) > 0 {
+		if _, ok := mapBodies[ctx[len(ctx)-1]]; ok {
+			return nil
+		}
+	}
+
+	for i := 0; i < len(*block); i++ {
+		stmt := (*block)[i]
+		if stmt.IsComment() {
+			continue
+		}
+		file := stmt.File
+		if file == "" {
+			file = w.payload.Config[cfg].File
+		}
+
+		site := &walkSite{
+			file: file, cfg: cfg, root: root, stmt: stmt, ctx: ctx,
+			parents: parents, block: block, index: i,
+		}
+		err := fn(site)
+		i = site.index
+		if errors.Is(err, errSkipDirective) {
+			i += site.added
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		for _, inc := range stmt.Includes {
+			if inc < 0 || inc >= len(w.payload.Config) || w.open[inc] || (w.once && w.reached[inc]) {
+				continue
+			}
+			if err := w.walkConfig(root, inc, ctx, parents, fn); err != nil {
+				return err
+			}
+		}
+
+		if stmt.IsBlock() {
+			inner := enterBlockCtx(stmt, append(blockCtx{}, ctx...), w.blockContexts)
+			innerParents := append(append([]*Directive{}, parents...), stmt)
+			if err := w.walkBlock(root, cfg, &stmt.Block, inner, innerParents, fn); err != nil {
+				return err
+			}
+		}
+		i += site.added
+	}
+	return nil
+}
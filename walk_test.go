@@ -0,0 +1,121 @@
+/**
+ * Copyright (c) F5, Inc.
+ *
+ * This source code is licensed under the Apache License, Version 2.0 license found in the
+ * LICENSE file in the root directory of this source tree.
+ */
+
+package crossplane
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// walkPayloadForTest builds a payload of the configs, where "include N" includes config N.
+func walkPayloadForTest(configs ...Directives) *Payload {
+	payload := &Payload{}
+	for i, parsed := range configs {
+		payload.Config = append(payload.Config, Config{File: string(rune('a'+i)) + ".conf", Parsed: parsed})
+	}
+	return payload
+}
+
+func includeConfig(i int) *Directive {
+	return &Directive{Directive: "include", Args: []string{"x"}, Includes: []int{i}}
+}
+
+func walkedSites(w *payloadWalker) []string {
+	var sites []string
+	_ = w.walk(func(s *walkSite) error {
+		sites = append(sites, s.file+" "+strings.Join(s.ctx, ">")+" "+s.stmt.Directive)
+		return nil
+	})
+	return sites
+}
+
+func TestPayloadWalker(t *testing.T) {
+	t.Parallel()
+	payload := walkPayloadForTest(
+		Directives{
+			{Directive: "http", Block: Directives{
+				{Directive: "server", Block: Directives{includeConfig(1)}},
+				{Directive: "server", Block: Directives{
+					includeConfig(1),
+					{Directive: "my_zone", Block: Directives{{Directive: "my_directive"}}},
+				}},
+				{Directive: "map", Args: []string{"$a", "$b"}, Block: Directives{{Directive: "default", Args: []string{"c"}}}},
+			}},
+		},
+		Directives{{Directive: "#", Comment: new(string)}, {Directive: "listen", Args: []string{"80"}}, includeConfig(0)},
+		Directives{{Directive: "user"}},
+	)
+
+	require.Equal(t, []string{
+		"a.conf  http",
+		"a.conf http server",
+		"a.conf http>server include",
+		"b.conf http>server listen",
+		// the include of a.conf would recurse
+		"b.conf http>server include",
+		"a.conf http server",
+		"a.conf http>server include",
+		"b.conf http>server listen",
+		"b.conf http>server include",
+		"a.conf http>server my_zone",
+		"a.conf http>server>my_zone my_directive",
+		"a.conf http map",
+		// configs that aren't included are walked in the main context
+		"c.conf  user",
+	}, walkedSites(newPayloadWalker(payload, nil, false)))
+
+	once := walkedSites(newPayloadWalker(payload, []BlockContext{{Path: []string{"http", "my_zone"}, Nested: true}}, true))
+	require.Equal(t, []string{
+		"a.conf  http",
+		"a.conf http server",
+		"a.conf http>server include",
+		"b.conf http>server listen",
+		"b.conf http>server include",
+		"a.conf http server",
+		"a.conf http>server include",
+		"a.conf http>server my_zone",
+		"a.conf http>my_zone my_directive",
+		"a.conf http map",
+		"c.conf  user",
+	}, once)
+}
+
+func TestPayloadWalkerReplace(t *testing.T) {
+	t.Parallel()
+	payload := walkPayloadForTest(Directives{
+		{Directive: "a"},
+		{Directive: "b", Block: Directives{{Directive: "c"}}},
+		{Directive: "d"},
+	})
+
+	var walked []string
+	_ = newPayloadWalker(payload, nil, true).walk(func(s *walkSite) error {
+		walked = append(walked, s.stmt.Directive)
+		switch s.stmt.Directive {
+		case "a":
+			// replaced by a directive that isn't walked
+			*s.block = append(Directives{{Directive: "added"}}, (*s.block)[1:]...)
+			return errSkipDirective
+		case "b":
+			// a directive is added after b
+			rest := append(Directives{}, (*s.block)[s.index+1:]...)
+			*s.block = append(append((*s.block)[:s.index+1], &Directive{Directive: "after"}), rest...)
+			s.added = 1
+		}
+		return nil
+	})
+	require.Equal(t, []string{"a", "b", "c", "d"}, walked)
+
+	var names []string
+	for _, stmt := range payload.Config[0].Parsed {
+		names = append(names, stmt.Directive)
+	}
+	require.Equal(t, []string{"added", "b", "after", "d"}, names)
+}